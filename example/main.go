@@ -18,27 +18,25 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
 )
 
 func main() {
-	// Get credentials from environment variables
-	clientID := os.Getenv("VERDA_CLIENT_ID")
-	clientSecret := os.Getenv("VERDA_CLIENT_SECRET")
-	baseURL := os.Getenv("VERDA_BASE_URL")
-
-	if clientID == "" || clientSecret == "" {
-		log.Fatal("VERDA_CLIENT_ID and VERDA_CLIENT_SECRET environment variables are required")
+	// Resolve credentials from VERDA_* environment variables or the config file
+	cfg, err := verda.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		log.Fatal("Set VERDA_CLIENT_ID and VERDA_CLIENT_SECRET, or add them to a profile in the config file")
 	}
+	fmt.Printf("Using %s from %s\n", cfg.BaseURL, cfg.Source(verda.SettingBaseURL))
 
 	// Create client
 	client, err := verda.NewClient(
-		verda.WithDebugLogging(true),
-		verda.WithBaseURL(baseURL),
-		verda.WithClientID(clientID),
-		verda.WithClientSecret(clientSecret))
+		verda.WithConfig(cfg),
+		verda.WithDebugLogging(true))
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
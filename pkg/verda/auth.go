@@ -16,8 +16,10 @@ package verda
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -26,9 +28,10 @@ import (
 )
 
 type AuthService struct {
-	client *Client
-	mu     sync.RWMutex
-	token  *TokenResponse
+	client   *Client
+	mu       sync.RWMutex
+	token    *TokenResponse
+	inflight *tokenCall
 }
 
 type TokenRequest struct {
@@ -47,49 +50,221 @@ type TokenResponse struct {
 	ExpiresAt    time.Time
 }
 
+// DefaultTokenRequestTimeout bounds a token request shared by concurrent
+// callers, which does not stop when any one of them gives up
+const DefaultTokenRequestTimeout = 30 * time.Second
+
+// tokenCall is a token request that concurrent callers wait on together
+type tokenCall struct {
+	done  chan struct{}
+	token *TokenResponse
+	err   error
+}
+
+// Authenticate fetches a new token with the client credentials. It is
+// AuthenticateContext with context.Background().
 func (s *AuthService) Authenticate() (*TokenResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	return s.AuthenticateContext(context.Background())
+}
+
+// AuthenticateContext fetches a new token with the client credentials
+func (s *AuthService) AuthenticateContext(ctx context.Context) (*TokenResponse, error) {
+	return s.fetchToken(ctx, func(*TokenResponse) TokenRequest {
+		return s.clientCredentials()
+	})
+}
+
+// RefreshToken is RefreshTokenContext with context.Background()
+func (s *AuthService) RefreshToken() (*TokenResponse, error) {
+	return s.RefreshTokenContext(context.Background())
+}
+
+// RefreshTokenContext exchanges the refresh token for a new token, or
+// authenticates again when there is none
+func (s *AuthService) RefreshTokenContext(ctx context.Context) (*TokenResponse, error) {
+	return s.fetchToken(ctx, func(current *TokenResponse) TokenRequest {
+		if current == nil || current.RefreshToken == "" {
+			return s.clientCredentials()
+		}
+		return TokenRequest{
+			GrantType:    "refresh_token",
+			RefreshToken: current.RefreshToken,
+			ClientID:     s.client.ClientID,
+			ClientSecret: s.client.ClientSecret,
+		}
+	})
+}
 
-	return s.doTokenRequest(TokenRequest{
+func (s *AuthService) clientCredentials() TokenRequest {
+	return TokenRequest{
 		GrantType:    "client_credentials",
 		ClientID:     s.client.ClientID,
 		ClientSecret: s.client.ClientSecret,
+	}
+}
+
+// fetchToken runs one token request at a time: callers arriving while one is
+// in flight wait for its result instead of sending their own. Each caller
+// stops waiting when its own context is done.
+func (s *AuthService) fetchToken(ctx context.Context, request func(current *TokenResponse) TokenRequest) (*TokenResponse, error) {
+	s.mu.Lock()
+	call := s.inflight
+	if call == nil {
+		call = &tokenCall{done: make(chan struct{})}
+		s.inflight = call
+		held := s.token
+		s.mu.Unlock()
+
+		go s.runTokenCall(ctx, call, held, request)
+	} else {
+		s.mu.Unlock()
+	}
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("authentication failed: %w", ctx.Err())
+	}
+}
+
+func (s *AuthService) runTokenCall(ctx context.Context, call *tokenCall, held *TokenResponse, request func(current *TokenResponse) TokenRequest) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), DefaultTokenRequestTimeout)
+	defer cancel()
+
+	if cache, ok := s.client.TokenCache.(LockingTokenCache); ok {
+		call.token, call.err = s.updateCachedToken(ctx, cache, held, request)
+	} else {
+		call.token, call.err = s.requestToken(ctx, request(held))
+		if call.err == nil {
+			s.storeCachedToken(ctx, call.token)
+		}
+	}
+
+	s.mu.Lock()
+	if call.err == nil {
+		s.token = call.token
+	}
+	s.inflight = nil
+	s.mu.Unlock()
+	close(call.done)
+}
+
+// requestToken sends body, authenticating again if a refresh is refused
+func (s *AuthService) requestToken(ctx context.Context, body TokenRequest) (*TokenResponse, error) {
+	token, err := s.doTokenRequest(ctx, body)
+	if err != nil && body.GrantType == "refresh_token" {
+		// The refresh token may have been used up, e.g. by another process
+		// sharing the token cache
+		token, err = s.doTokenRequest(ctx, s.clientCredentials())
+	}
+	return token, err
+}
+
+// updateCachedToken fetches a token while holding the cache lock. A token
+// that another process stored while this one waited for the lock is adopted
+// instead of being fetched again.
+func (s *AuthService) updateCachedToken(ctx context.Context, cache LockingTokenCache, held *TokenResponse, request func(current *TokenResponse) TokenRequest) (*TokenResponse, error) {
+	var requested bool
+	token, err := cache.Update(s.cacheKey(), func(cached *TokenResponse) (*TokenResponse, error) {
+		if cached != nil && !tokenExpiring(cached) && (held == nil || cached.AccessToken != held.AccessToken) {
+			return cached, nil
+		}
+		if cached == nil {
+			cached = held
+		}
+		requested = true
+		return s.requestToken(ctx, request(cached))
 	})
+
+	switch {
+	case token != nil:
+		if err != nil {
+			s.logCacheError(ctx, err)
+		}
+		return token, nil
+	case requested:
+		return nil, err
+	default:
+		// The cache could not be locked or read; fetch without it
+		s.logCacheError(ctx, err)
+		return s.requestToken(ctx, request(held))
+	}
 }
 
-func (s *AuthService) RefreshToken() (*TokenResponse, error) {
+func (s *AuthService) cacheKey() string {
+	return tokenCacheKey(s.client.BaseURL, s.client.ClientID)
+}
+
+// loadCachedToken adopts the token in the client's TokenCache, if any, when
+// no token has been fetched yet
+func (s *AuthService) loadCachedToken(ctx context.Context) *TokenResponse {
+	if s.client.TokenCache == nil {
+		return nil
+	}
+
+	token, err := s.client.TokenCache.Load(s.cacheKey())
+	if err != nil {
+		s.logCacheError(ctx, err)
+		return nil
+	}
+	if token == nil {
+		return nil
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if s.token == nil {
+		s.token = token
+	}
+	return s.token
+}
 
-	if s.token == nil || s.token.RefreshToken == "" {
-		return s.authenticateWithoutLock()
+func (s *AuthService) storeCachedToken(ctx context.Context, token *TokenResponse) {
+	if s.client.TokenCache == nil {
+		return
+	}
+	if err := s.client.TokenCache.Store(s.cacheKey(), token); err != nil {
+		s.logCacheError(ctx, err)
 	}
+}
 
-	return s.doTokenRequest(TokenRequest{
-		GrantType:    "refresh_token",
-		RefreshToken: s.token.RefreshToken,
-		ClientID:     s.client.ClientID,
-		ClientSecret: s.client.ClientSecret,
-	})
+// Cache failures are not fatal: the token is simply fetched again next time
+func (s *AuthService) logCacheError(ctx context.Context, err error) {
+	s.client.structuredLogger().LogAttrs(ctx, slog.LevelWarn, "token cache failed", slog.Any(LogKeyError, err))
 }
 
-func (s *AuthService) authenticateWithoutLock() (*TokenResponse, error) {
-	return s.doTokenRequest(TokenRequest{
-		GrantType:    "client_credentials",
-		ClientID:     s.client.ClientID,
-		ClientSecret: s.client.ClientSecret,
-	})
+// Invalidate drops the cached token if it is still accessToken, which the API
+// rejected, so the next call fetches a new one. It always reports true: either
+// a new token will be fetched or another caller already replaced it.
+func (s *AuthService) Invalidate(accessToken string) bool {
+	s.mu.Lock()
+	current := s.token != nil && s.token.AccessToken == accessToken
+	if current {
+		s.token = nil
+	}
+	s.mu.Unlock()
+
+	if current && s.client.TokenCache != nil {
+		if err := s.client.TokenCache.Delete(s.cacheKey()); err != nil {
+			s.logCacheError(context.Background(), err)
+		}
+	}
+	return true
 }
 
 // doTokenRequest tries JSON first (production), falls back to form-encoded (staging quirk)
-func (s *AuthService) doTokenRequest(body TokenRequest) (*TokenResponse, error) {
+func (s *AuthService) doTokenRequest(ctx context.Context, body TokenRequest) (token *TokenResponse, err error) {
+	defer func() {
+		s.client.observeTokenRefresh(body.GrantType, err == nil)
+	}()
+
 	payload, err := json.Marshal(body) //nolint:gosec // G117: OAuth token request must include client_secret
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal token request: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, s.client.BaseURL+"/oauth2/token", bytes.NewReader(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.client.BaseURL+"/oauth2/token", bytes.NewReader(payload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token request: %w", err)
 	}
@@ -99,7 +274,7 @@ func (s *AuthService) doTokenRequest(body TokenRequest) (*TokenResponse, error)
 		req.Header.Set("Authorization", "Bearer "+s.client.AuthBearerToken)
 	}
 
-	resp, err := s.client.HTTPClient.Do(req)
+	resp, err := s.client.limitedRoundTrip(req, nil)
 	if err != nil {
 		return nil, fmt.Errorf("authentication request failed: %w", err)
 	}
@@ -108,7 +283,6 @@ func (s *AuthService) doTokenRequest(body TokenRequest) (*TokenResponse, error)
 	err = s.client.handleResponse(resp, &tokenResp)
 	if err == nil {
 		tokenResp.ExpiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
-		s.token = &tokenResp
 		return &tokenResp, nil
 	}
 
@@ -128,7 +302,7 @@ func (s *AuthService) doTokenRequest(body TokenRequest) (*TokenResponse, error)
 				form.Set("refresh_token", body.RefreshToken)
 			}
 
-			req2, err2 := http.NewRequest(http.MethodPost, s.client.BaseURL+"/oauth2/token", strings.NewReader(form.Encode()))
+			req2, err2 := http.NewRequestWithContext(ctx, http.MethodPost, s.client.BaseURL+"/oauth2/token", strings.NewReader(form.Encode()))
 			if err2 != nil {
 				return nil, fmt.Errorf("failed to create token request (form): %w", err2)
 			}
@@ -137,14 +311,13 @@ func (s *AuthService) doTokenRequest(body TokenRequest) (*TokenResponse, error)
 			if s.client.AuthBearerToken != "" {
 				req2.Header.Set("Authorization", "Bearer "+s.client.AuthBearerToken)
 			}
-			resp2, err2 := s.client.HTTPClient.Do(req2)
+			resp2, err2 := s.client.limitedRoundTrip(req2, nil)
 			if err2 != nil {
 				return nil, fmt.Errorf("authentication request failed (form): %w", err2)
 			}
 			var tokenResp2 TokenResponse
 			if err3 := s.client.handleResponse(resp2, &tokenResp2); err3 == nil {
 				tokenResp2.ExpiresAt = time.Now().Add(time.Duration(tokenResp2.ExpiresIn) * time.Second)
-				s.token = &tokenResp2
 				return &tokenResp2, nil
 			}
 		}
@@ -152,18 +325,27 @@ func (s *AuthService) doTokenRequest(body TokenRequest) (*TokenResponse, error)
 	return nil, fmt.Errorf("authentication failed: %w", err)
 }
 
+// GetValidToken is GetValidTokenContext with context.Background()
 func (s *AuthService) GetValidToken() (*TokenResponse, error) {
+	return s.GetValidTokenContext(context.Background())
+}
+
+// GetValidTokenContext returns the current token, fetching or refreshing it
+// when needed
+func (s *AuthService) GetValidTokenContext(ctx context.Context) (*TokenResponse, error) {
 	s.mu.RLock()
 	token := s.token
 	s.mu.RUnlock()
 
 	if token == nil {
-		return s.Authenticate()
+		token = s.loadCachedToken(ctx)
+	}
+	if token == nil {
+		return s.AuthenticateContext(ctx)
 	}
 
-	// Refresh 30s before expiry to avoid races
-	if time.Now().Add(30 * time.Second).After(token.ExpiresAt) {
-		return s.RefreshToken()
+	if tokenExpiring(token) {
+		return s.RefreshTokenContext(ctx)
 	}
 
 	return token, nil
@@ -173,16 +355,24 @@ func (s *AuthService) IsExpired() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if s.token == nil {
-		return true
-	}
+	return s.token == nil || tokenExpiring(s.token)
+}
 
-	// Consider expired 30s before actual expiry
-	return time.Now().Add(30 * time.Second).After(s.token.ExpiresAt)
+// tokenExpiring reports whether token expires within 30s, which is treated as
+// expired to avoid races
+func tokenExpiring(token *TokenResponse) bool {
+	return time.Now().Add(30 * time.Second).After(token.ExpiresAt)
 }
 
+// GetBearerToken is GetBearerTokenContext with context.Background()
 func (s *AuthService) GetBearerToken() (string, error) {
-	token, err := s.GetValidToken()
+	return s.GetBearerTokenContext(context.Background())
+}
+
+// GetBearerTokenContext returns the Authorization header value for the
+// current token
+func (s *AuthService) GetBearerTokenContext(ctx context.Context) (string, error) {
+	token, err := s.GetValidTokenContext(ctx)
 	if err != nil {
 		return "", err
 	}
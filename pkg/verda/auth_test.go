@@ -15,9 +15,14 @@
 package verda
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -224,6 +229,64 @@ func TestAuthService_GetValidToken(t *testing.T) {
 	})
 }
 
+func TestAuthService_ContextVariants(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	t.Run("context variants return tokens", func(t *testing.T) {
+		client := NewTestClient(mockServer)
+		ctx := context.Background()
+
+		if _, err := client.Auth.AuthenticateContext(ctx); err != nil {
+			t.Fatalf("AuthenticateContext: unexpected error: %v", err)
+		}
+		if _, err := client.Auth.RefreshTokenContext(ctx); err != nil {
+			t.Fatalf("RefreshTokenContext: unexpected error: %v", err)
+		}
+		token, err := client.Auth.GetValidTokenContext(ctx)
+		if err != nil {
+			t.Fatalf("GetValidTokenContext: unexpected error: %v", err)
+		}
+		bearer, err := client.Auth.GetBearerTokenContext(ctx)
+		if err != nil {
+			t.Fatalf("GetBearerTokenContext: unexpected error: %v", err)
+		}
+		if bearer != "Bearer "+token.AccessToken {
+			t.Errorf("expected bearer token for %q, got %q", token.AccessToken, bearer)
+		}
+	})
+
+	t.Run("cancelled contexts stop token requests", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := map[string]func(*AuthService) error{
+			"AuthenticateContext": func(s *AuthService) error {
+				_, err := s.AuthenticateContext(ctx)
+				return err
+			},
+			"RefreshTokenContext": func(s *AuthService) error {
+				_, err := s.RefreshTokenContext(ctx)
+				return err
+			},
+			"GetValidTokenContext": func(s *AuthService) error {
+				_, err := s.GetValidTokenContext(ctx)
+				return err
+			},
+			"GetBearerTokenContext": func(s *AuthService) error {
+				_, err := s.GetBearerTokenContext(ctx)
+				return err
+			},
+		}
+		for name, call := range calls {
+			client := NewTestClient(mockServer)
+			if err := call(client.Auth); !errors.Is(err, context.Canceled) {
+				t.Errorf("%s: expected context.Canceled, got %v", name, err)
+			}
+		}
+	})
+}
+
 func TestAuthService_IsExpired(t *testing.T) {
 	mockServer := testutil.NewMockServer()
 	defer mockServer.Close()
@@ -287,3 +350,116 @@ func TestAuthService_GetBearerToken(t *testing.T) {
 		}
 	})
 }
+
+// tokenHandler issues a distinct token per call, after waiting on release
+func tokenHandler(calls *int32, release <-chan struct{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+		if release != nil {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}
+}
+
+func TestAuthService_Singleflight(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var calls int32
+	release := make(chan struct{})
+	mockServer.SetHandler(http.MethodPost, "/oauth2/token", tokenHandler(&calls, release))
+	client := NewTestClient(mockServer)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	tokens := make([]string, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokens[i], errs[i] = client.Auth.GetBearerTokenContext(context.Background())
+		}()
+	}
+
+	// Let every caller queue up behind the first request
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected 1 token request, got %d", n)
+	}
+	for i := range callers {
+		if errs[i] != nil || tokens[i] != "Bearer token-1" {
+			t.Errorf("caller %d: got %q, %v", i, tokens[i], errs[i])
+		}
+	}
+}
+
+func TestAuthService_ContextDeadline(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var calls int32
+	release := make(chan struct{})
+	defer close(release)
+	mockServer.SetHandler(http.MethodPost, "/oauth2/token", tokenHandler(&calls, release))
+	client := NewTestClient(mockServer)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Balance.Get(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the caller's deadline to end the wait, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to return at the deadline, took %v", elapsed)
+	}
+}
+
+func TestClient_ReplaysOnUnauthorized(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var tokenCalls int32
+	mockServer.SetHandler(http.MethodPost, "/oauth2/token", tokenHandler(&tokenCalls, nil))
+
+	var rejected []string
+	mockServer.SetHandler(http.MethodGet, "/balance", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token-2" {
+			rejected = append(rejected, auth)
+			testutil.ErrorResponse(w, http.StatusUnauthorized, "token revoked")
+			return
+		}
+		_, _ = w.Write([]byte(`{"amount":1,"currency":"eur"}`))
+	})
+	client := NewTestClient(mockServer)
+
+	t.Run("a rejected token is replaced and the call replayed", func(t *testing.T) {
+		if _, err := client.Balance.Get(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(rejected) != 1 || rejected[0] != "Bearer token-1" || atomic.LoadInt32(&tokenCalls) != 2 {
+			t.Errorf("expected one rejection and two token requests, got %v and %d", rejected, tokenCalls)
+		}
+	})
+
+	t.Run("the call is replayed only once", func(t *testing.T) {
+		mockServer.SetHandler(http.MethodGet, "/balance", func(w http.ResponseWriter, _ *http.Request) {
+			testutil.ErrorResponse(w, http.StatusUnauthorized, "token revoked")
+		})
+
+		var apiErr *APIError
+		if _, err := client.Balance.Get(context.Background()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected the 401 to be returned, got %v", err)
+		}
+		if n := atomic.LoadInt32(&tokenCalls); n != 3 {
+			t.Errorf("expected one more token request, got %d in total", n)
+		}
+	})
+}
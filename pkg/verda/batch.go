@@ -0,0 +1,110 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ActionBatchSize is the largest number of resources the SDK puts in a
+// single action request; longer ID lists are sent in several requests
+const ActionBatchSize = 50
+
+// BatchActionError is returned when an action on several resources failed
+// for some of them. The other resources were acted on.
+type BatchActionError struct {
+	Kind   ResourceKind
+	Action string
+	// Total is the number of resources the action was requested for
+	Total    int
+	Failures []BatchFailure
+}
+
+// BatchFailure describes why an action failed for one resource
+type BatchFailure struct {
+	ID         string
+	Message    string
+	StatusCode int
+}
+
+func (e *BatchActionError) Error() string {
+	failures := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		failures[i] = f.ID + ": " + f.Message
+		if f.StatusCode != 0 {
+			failures[i] += fmt.Sprintf(" (%d)", f.StatusCode)
+		}
+	}
+	return fmt.Sprintf("%s failed for %d of %d %ss: %s", e.Action, len(e.Failures), e.Total, e.Kind, strings.Join(failures, "; "))
+}
+
+// FailedIDs returns the IDs of the resources the action failed for
+func (e *BatchActionError) FailedIDs() []string {
+	ids := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		ids[i] = f.ID
+	}
+	return ids
+}
+
+// BatchStoppedError is returned when an action on several resources stopped
+// because one of its requests failed as a whole. The first Done resources, in
+// the order given, were sent in earlier requests; the rest were not.
+type BatchStoppedError struct {
+	Kind   ResourceKind
+	Action string
+	Done   int
+	Total  int
+	Err    error
+}
+
+func (e *BatchStoppedError) Error() string {
+	return fmt.Sprintf("%s stopped after %d of %d %ss: %v", e.Action, e.Done, e.Total, e.Kind, e.Err)
+}
+
+func (e *BatchStoppedError) Unwrap() error {
+	return e.Err
+}
+
+// batchStopped wraps err from a failed request of an action once earlier
+// requests went through, so callers learn how far the action got
+func batchStopped(kind ResourceKind, action string, done, total int, err error) error {
+	if done == 0 {
+		return err
+	}
+	return &BatchStoppedError{Kind: kind, Action: action, Done: done, Total: total, Err: err}
+}
+
+// chunkIDs splits ids into slices of at most ActionBatchSize IDs
+func chunkIDs(ids []string) [][]string {
+	if len(ids) == 0 {
+		return [][]string{ids}
+	}
+	return slices.Collect(slices.Chunk(ids, ActionBatchSize))
+}
+
+// chunkContext gives chunk i of n its own idempotency key when an action
+// takes several requests, so that the API does not answer the later chunks
+// with the stored response of the first
+func chunkContext(ctx context.Context, i, n int) context.Context {
+	if n == 1 {
+		return ctx
+	}
+	return withIdempotencyKeySuffix(ctx, strconv.Itoa(i))
+}
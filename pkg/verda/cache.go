@@ -0,0 +1,340 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheMaxEntries is the size of the default in-memory LRU store
+const DefaultCacheMaxEntries = 1024
+
+// CacheStatusHeader is set to "hit" on responses served from the cache
+const CacheStatusHeader = "X-Verda-Cache"
+
+// DefaultCacheTTLs returns the TTLs used for catalog endpoints when
+// CacheConfig.TTLs does not override them
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"/instance-types":    5 * time.Minute,
+		"/container-types":   5 * time.Minute,
+		"/cluster-types":     5 * time.Minute,
+		"/images":            15 * time.Minute,
+		"/volume-types":      15 * time.Minute,
+		"/locations":         time.Hour,
+		"/long-term/periods": time.Hour,
+	}
+}
+
+// CacheEntry is a cached API response
+type CacheEntry struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	ExpiresAt  time.Time
+}
+
+// CacheStore is the storage behind a ResponseCache. Implementations must be
+// safe for concurrent use. Keys look like
+// "/instance-types?currency=eur#https://api.verda.com/v1 client:abc123".
+type CacheStore interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+	// DeletePrefix removes every key starting with prefix
+	DeletePrefix(prefix string)
+}
+
+// CacheConfig configures a ResponseCache
+type CacheConfig struct {
+	// TTLs maps API path prefixes to how long their GET responses are cached.
+	// Entries are merged over DefaultCacheTTLs; a zero TTL disables caching
+	// for that prefix. When several prefixes match, the longest wins.
+	TTLs map[string]time.Duration
+	// Store holds the cached responses. Defaults to an in-memory LRU store of
+	// MaxEntries entries.
+	Store CacheStore
+	// MaxEntries sizes the default store. Defaults to 1024.
+	MaxEntries int
+}
+
+// ResponseCache caches successful GET responses of slow-changing catalog
+// endpoints. Only paths with a TTL are cached, so mutable resources such as
+// instances and volumes always go to the API. A POST, PUT, PATCH or DELETE to
+// a cached path invalidates that path's entries.
+type ResponseCache struct {
+	store CacheStore
+	ttls  []prefixTTL // sorted longest prefix first
+	now   func() time.Time
+}
+
+type prefixTTL struct {
+	prefix string
+	ttl    time.Duration
+}
+
+// NewResponseCache creates a response cache, filling in defaults for zero fields
+func NewResponseCache(config CacheConfig) *ResponseCache {
+	store := config.Store
+	if store == nil {
+		maxEntries := config.MaxEntries
+		if maxEntries <= 0 {
+			maxEntries = DefaultCacheMaxEntries
+		}
+		store = NewLRUCacheStore(maxEntries)
+	}
+
+	merged := DefaultCacheTTLs()
+	for prefix, ttl := range config.TTLs {
+		merged["/"+strings.Trim(prefix, "/")] = ttl
+	}
+
+	rc := &ResponseCache{store: store, now: time.Now}
+	for prefix, ttl := range merged {
+		rc.ttls = append(rc.ttls, prefixTTL{prefix: prefix, ttl: ttl})
+	}
+	sort.Slice(rc.ttls, func(i, j int) bool {
+		if len(rc.ttls[i].prefix) != len(rc.ttls[j].prefix) {
+			return len(rc.ttls[i].prefix) > len(rc.ttls[j].prefix)
+		}
+		return rc.ttls[i].prefix < rc.ttls[j].prefix
+	})
+	return rc
+}
+
+// WithResponseCache enables response caching for catalog endpoints
+func WithResponseCache(cache *ResponseCache) ClientOption {
+	return func(c *Client) {
+		c.Cache = cache
+	}
+}
+
+// Invalidate drops every cached response for path and the paths below it,
+// whatever their query parameters
+func (rc *ResponseCache) Invalidate(path string) {
+	path = "/" + strings.Trim(path, "/")
+	rc.store.Delete(path)
+	rc.store.DeletePrefix(path + "?")
+	rc.store.DeletePrefix(path + "/")
+}
+
+// InvalidateAll empties the cache
+func (rc *ResponseCache) InvalidateAll() {
+	rc.store.DeletePrefix("")
+}
+
+// ruleFor returns the longest configured prefix matching path on a segment
+// boundary and its TTL
+func (rc *ResponseCache) ruleFor(path string) (prefixTTL, bool) {
+	for _, r := range rc.ttls {
+		if path == r.prefix || strings.HasPrefix(path, r.prefix+"/") {
+			return r, r.ttl > 0
+		}
+	}
+	return prefixTTL{}, false
+}
+
+// cacheKey builds the store key from the API path, the sorted query string and
+// the client's base URL and credentials, so "?currency=eur" and "?currency=usd"
+// are cached separately, and clients sharing a cache never see each other's
+// responses. The scope goes last so Invalidate can match on the path.
+func (c *Client) cacheKey(path string, req *http.Request) string {
+	return path + "?" + req.URL.Query().Encode() + "#" + c.BaseURL + " " + c.cacheIdentity
+}
+
+// responseCacheIdentity names the credentials behind c's cached responses: the
+// client ID, or a random ID when a custom TokenSource hides who is calling
+func responseCacheIdentity(c *Client) string {
+	if auth, ok := c.TokenSource.(*AuthService); ok {
+		return "client:" + auth.client.ClientID
+	}
+	return "source:" + NewIdempotencyKey()
+}
+
+type cacheRefreshKey struct{}
+
+// WithCacheRefresh returns a context whose requests skip cached responses and
+// store fresh ones in their place
+func WithCacheRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheRefreshKey{}, true)
+}
+
+func cacheRefreshFromContext(ctx context.Context) bool {
+	refresh, _ := ctx.Value(cacheRefreshKey{}).(bool)
+	return refresh || requestOptionsFrom(ctx).noCache
+}
+
+// cachedResponse returns a cached response for req, if there is a fresh one
+func (c *Client) cachedResponse(req *http.Request) (*http.Response, []byte, bool) {
+	rc := c.Cache
+	if rc == nil || req.Method != http.MethodGet || cacheRefreshFromContext(req.Context()) {
+		return nil, nil, false
+	}
+
+	path := c.relativePath(req.URL.Path)
+	if _, ok := rc.ruleFor(path); !ok {
+		return nil, nil, false
+	}
+
+	key := c.cacheKey(path, req)
+	entry, ok := rc.store.Get(key)
+	if !ok {
+		return nil, nil, false
+	}
+	if !rc.now().Before(entry.ExpiresAt) {
+		rc.store.Delete(key)
+		return nil, nil, false
+	}
+
+	header := entry.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set(CacheStatusHeader, "hit")
+
+	resp := &http.Response{
+		Status:        http.StatusText(entry.StatusCode),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          http.NoBody,
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}
+	return resp, entry.Body, true
+}
+
+// updateCache stores a successful GET response or, for a mutating request,
+// invalidates the cached entries it may have changed
+func (c *Client) updateCache(req *http.Request, resp *http.Response, body []byte) {
+	rc := c.Cache
+	if rc == nil {
+		return
+	}
+
+	path := c.relativePath(req.URL.Path)
+	rule, ok := rc.ruleFor(path)
+	if !ok {
+		return
+	}
+
+	if isMutatingMethod(req.Method) {
+		rc.Invalidate(rule.prefix)
+		return
+	}
+	if req.Method != http.MethodGet || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return
+	}
+
+	rc.store.Set(c.cacheKey(path, req), &CacheEntry{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       append([]byte(nil), body...),
+		ExpiresAt:  rc.now().Add(rule.ttl),
+	})
+}
+
+// LRUCacheStore is an in-memory CacheStore that evicts the least recently
+// used entry once it holds maxEntries
+type LRUCacheStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // front is most recently used
+	items      map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewLRUCacheStore creates an in-memory store holding at most maxEntries responses
+func NewLRUCacheStore(maxEntries int) *LRUCacheStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheMaxEntries
+	}
+	return &LRUCacheStore{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (s *LRUCacheStore) Get(key string) (*CacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	s.order.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+func (s *LRUCacheStore) Set(key string, entry *CacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		el.Value.(*lruItem).entry = entry
+		s.order.MoveToFront(el)
+		return
+	}
+
+	s.items[key] = s.order.PushFront(&lruItem{key: key, entry: entry})
+	for s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.items, oldest.Value.(*lruItem).key)
+	}
+}
+
+func (s *LRUCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		s.order.Remove(el)
+		delete(s.items, key)
+	}
+}
+
+func (s *LRUCacheStore) DeletePrefix(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, el := range s.items {
+		if strings.HasPrefix(key, prefix) {
+			s.order.Remove(el)
+			delete(s.items, key)
+		}
+	}
+}
+
+// Len returns the number of cached responses
+func (s *LRUCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
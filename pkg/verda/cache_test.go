@@ -0,0 +1,329 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+// countingHandler answers with body and counts how often it was called
+func countingHandler(calls *int32, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func newCacheTestClient(mockServer *testutil.MockServer, config CacheConfig) (*Client, *ResponseCache, *fakeClock) {
+	cache := NewResponseCache(config)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cache.now = clock.Now
+	return newRateLimitedTestClient(mockServer, WithResponseCache(cache)), cache, clock
+}
+
+func TestLRUCacheStore(t *testing.T) {
+	t.Run("evicts the least recently used entry", func(t *testing.T) {
+		s := NewLRUCacheStore(2)
+		s.Set("a", &CacheEntry{})
+		s.Set("b", &CacheEntry{})
+		s.Get("a")
+		s.Set("c", &CacheEntry{})
+
+		if _, ok := s.Get("b"); ok {
+			t.Error("expected b to be evicted")
+		}
+		for _, key := range []string{"a", "c"} {
+			if _, ok := s.Get(key); !ok {
+				t.Errorf("expected %s to be kept", key)
+			}
+		}
+	})
+
+	t.Run("delete prefix", func(t *testing.T) {
+		s := NewLRUCacheStore(10)
+		s.Set("/images?", &CacheEntry{})
+		s.Set("/images?instance_type=1V100.6V", &CacheEntry{})
+		s.Set("/images/cluster?", &CacheEntry{})
+		s.Set("/locations?", &CacheEntry{})
+
+		s.DeletePrefix("/images?")
+		if s.Len() != 2 {
+			t.Errorf("expected 2 entries left, got %d", s.Len())
+		}
+		if _, ok := s.Get("/images/cluster?"); !ok {
+			t.Error("expected /images/cluster to be kept")
+		}
+	})
+}
+
+func TestResponseCache_RuleFor(t *testing.T) {
+	rc := NewResponseCache(CacheConfig{TTLs: map[string]time.Duration{
+		"/images/cluster": time.Second,
+		"locations":       0,
+	}})
+
+	tests := []struct {
+		path   string
+		want   time.Duration
+		cached bool
+	}{
+		{"/instance-types", 5 * time.Minute, true},
+		{"/instance-types/1V100.6V", 5 * time.Minute, true},
+		{"/images", 15 * time.Minute, true},
+		{"/images/cluster", time.Second, true},
+		{"/locations", 0, false},
+		{"/instances", 0, false},
+		{"/instance-availability", 0, false},
+	}
+	for _, tt := range tests {
+		rule, ok := rc.ruleFor(tt.path)
+		if ok != tt.cached || (ok && rule.ttl != tt.want) {
+			t.Errorf("ruleFor(%q) = %v, %v; expected %v, %v", tt.path, rule.ttl, ok, tt.want, tt.cached)
+		}
+	}
+}
+
+func TestClient_ResponseCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("catalog responses are served from the cache", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/locations", countingHandler(&calls, `[{"code":"FIN-01"}]`))
+		client, _, _ := newCacheTestClient(mockServer, CacheConfig{})
+
+		for i := 0; i < 3; i++ {
+			locations, err := client.Locations.Get(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(locations) != 1 || locations[0].Code != "FIN-01" {
+				t.Fatalf("unexpected locations: %+v", locations)
+			}
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("expected 1 API call, got %d", n)
+		}
+	})
+
+	t.Run("query parameters are part of the key", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/instance-types", countingHandler(&calls, `[]`))
+		client, _, _ := newCacheTestClient(mockServer, CacheConfig{})
+
+		for _, currency := range []string{"eur", "usd", "eur", "usd"} {
+			if _, err := client.InstanceTypes.Get(ctx, currency); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("expected one API call per currency, got %d", n)
+		}
+	})
+
+	t.Run("clients share entries only with the same base URL and credentials", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		otherServer := testutil.NewMockServer()
+		defer otherServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/locations", countingHandler(&calls, `[]`))
+		otherServer.SetHandler(http.MethodGet, "/locations", countingHandler(&calls, `[]`))
+		cache := NewResponseCache(CacheConfig{})
+		config := testutil.NewTestClientConfig(mockServer)
+		newClient := func(options ...ClientOption) *Client {
+			client, _ := NewClient(append([]ClientOption{
+				WithBaseURL(config.BaseURL), WithClientID(config.ClientID), WithClientSecret(config.ClientSecret), WithResponseCache(cache),
+			}, options...)...)
+			return client
+		}
+
+		clients := []*Client{
+			newClient(),
+			newClient(),
+			newClient(WithClientID("other-client")),
+			newClient(WithBaseURL(otherServer.URL())),
+			newClient(WithStaticToken("pre-issued")),
+		}
+		for _, client := range clients {
+			if _, err := client.Locations.Get(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if n := atomic.LoadInt32(&calls); n != 4 {
+			t.Errorf("expected only the identical clients to share an entry, got %d API calls", n)
+		}
+	})
+
+	t.Run("entries expire after their TTL", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/volume-types", countingHandler(&calls, `[]`))
+		client, _, clock := newCacheTestClient(mockServer, CacheConfig{TTLs: map[string]time.Duration{"/volume-types": time.Minute}})
+
+		_, _ = client.VolumeTypes.GetAllVolumeTypes(ctx)
+		clock.Advance(59 * time.Second)
+		_, _ = client.VolumeTypes.GetAllVolumeTypes(ctx)
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Fatalf("expected a hit before the TTL, got %d calls", n)
+		}
+
+		clock.Advance(time.Second)
+		_, _ = client.VolumeTypes.GetAllVolumeTypes(ctx)
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("expected a miss after the TTL, got %d calls", n)
+		}
+	})
+
+	t.Run("mutable resources bypass the cache", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/volumes", countingHandler(&calls, `[]`))
+		client, _, _ := newCacheTestClient(mockServer, CacheConfig{})
+
+		for i := 0; i < 3; i++ {
+			if _, err := client.Volumes.ListVolumes(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if n := atomic.LoadInt32(&calls); n != 3 {
+			t.Errorf("expected every call to reach the API, got %d", n)
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/images", func(w http.ResponseWriter, _ *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			testutil.ErrorResponse(w, http.StatusInternalServerError, "boom")
+		})
+		client, _, _ := newCacheTestClient(mockServer, CacheConfig{})
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.Images.Get(ctx); err == nil {
+				t.Fatal("expected an error")
+			}
+		}
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("expected both calls to reach the API, got %d", n)
+		}
+	})
+
+	t.Run("context forces a refresh", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/long-term/periods", countingHandler(&calls, `[]`))
+		client, _, _ := newCacheTestClient(mockServer, CacheConfig{})
+
+		_, _ = client.LongTerm.GetPeriods(ctx)
+		_, _ = client.LongTerm.GetPeriods(WithCacheRefresh(ctx))
+		_, _ = client.LongTerm.GetPeriods(ctx)
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("expected the refresh to reach the API and be reused, got %d calls", n)
+		}
+	})
+
+	t.Run("explicit invalidation", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/container-types", countingHandler(&calls, `[]`))
+		client, cache, _ := newCacheTestClient(mockServer, CacheConfig{})
+
+		_, _ = client.ContainerTypes.Get(ctx, "eur")
+		_, _ = client.ContainerTypes.Get(ctx, "usd")
+		cache.Invalidate("/container-types")
+		_, _ = client.ContainerTypes.Get(ctx, "eur")
+		_, _ = client.ContainerTypes.Get(ctx, "usd")
+		if n := atomic.LoadInt32(&calls); n != 4 {
+			t.Errorf("expected every variant to be invalidated, got %d calls", n)
+		}
+
+		cache.InvalidateAll()
+		_, _ = client.ContainerTypes.Get(ctx, "eur")
+		if n := atomic.LoadInt32(&calls); n != 5 {
+			t.Errorf("expected InvalidateAll to empty the cache, got %d calls", n)
+		}
+	})
+
+	t.Run("mutating requests invalidate the path", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/images", countingHandler(&calls, `[]`))
+		mockServer.SetHandler(http.MethodPost, "/images", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+		client, _, _ := newCacheTestClient(mockServer, CacheConfig{})
+
+		_, _ = client.Images.Get(ctx)
+		if _, err := postRequestAllowEmptyResponse(ctx, client, "/images", map[string]string{"name": "custom"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, _ = client.Images.Get(ctx)
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("expected the POST to invalidate /images, got %d calls", n)
+		}
+	})
+
+	t.Run("cached responses are marked", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/locations", countingHandler(&calls, `[]`))
+		client, _, _ := newCacheTestClient(mockServer, CacheConfig{})
+
+		_, first, err := getRequest[[]Location](ctx, client, "/locations")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, second, err := getRequest[[]Location](ctx, client, "/locations")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first.Header.Get(CacheStatusHeader) != "" {
+			t.Error("expected the first response to come from the API")
+		}
+		if second.Header.Get(CacheStatusHeader) != "hit" {
+			t.Error("expected the second response to be marked as a cache hit")
+		}
+	})
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Circuit breaker defaults used when a CircuitBreakerConfig field is zero
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitCooldown         = 30 * time.Second
+)
+
+// ErrCircuitOpen is matched (via errors.Is) by every error returned because a
+// circuit breaker refused to send a request
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit for one endpoint group
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through and counts consecutive failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails requests immediately until the cooldown has passed
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through; a
+	// success closes the circuit and a failure opens it again
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitOpenError is returned when a request is rejected by an open circuit
+type CircuitOpenError struct {
+	Group string
+	// RetryAt is when the circuit will next let a probe request through
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open for %s until %s", e.Group, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) match
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerConfig configures a CircuitBreaker
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive 5xx responses or transport
+	// errors that trips the circuit. Defaults to 5.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before going half-open.
+	// Defaults to 30s.
+	Cooldown time.Duration
+	// HalfOpenRequests is the number of concurrent probe requests allowed while
+	// half-open. Defaults to 1.
+	HalfOpenRequests int
+	// OnStateChange, when set, is called after every state transition. It is
+	// called synchronously, so it should not block.
+	OnStateChange func(group string, from, to CircuitState)
+}
+
+// CircuitBreaker tracks failures per endpoint group ("/instances", "/volumes",
+// ...) and fails fast while a group is unhealthy. It is safe for concurrent
+// use and may be shared between clients.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu     sync.Mutex
+	groups map[string]*circuit
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// NewCircuitBreaker creates a circuit breaker, filling in defaults for zero fields
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultCircuitFailureThreshold
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = DefaultCircuitCooldown
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = 1
+	}
+	return &CircuitBreaker{
+		config: config,
+		now:    time.Now,
+		groups: make(map[string]*circuit),
+	}
+}
+
+// WithCircuitBreaker protects the client with cb. Every HTTP attempt, including
+// retries, is checked against and recorded in the breaker.
+func WithCircuitBreaker(cb *CircuitBreaker) ClientOption {
+	return func(c *Client) {
+		c.CircuitBreaker = cb
+	}
+}
+
+// State returns the current state of the circuit for group
+func (cb *CircuitBreaker) State(group string) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c, ok := cb.groups[group]
+	if !ok {
+		return CircuitClosed
+	}
+	if c.state == CircuitOpen && !cb.now().Before(c.openedAt.Add(cb.config.Cooldown)) {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// Allow reports whether a request to group may be sent. A nil error must be
+// followed by exactly one call to Record with the outcome.
+func (cb *CircuitBreaker) Allow(group string) error {
+	cb.mu.Lock()
+	c := cb.circuitFor(group)
+	from := c.state
+
+	switch c.state {
+	case CircuitOpen:
+		retryAt := c.openedAt.Add(cb.config.Cooldown)
+		if cb.now().Before(retryAt) {
+			cb.mu.Unlock()
+			return &CircuitOpenError{Group: group, RetryAt: retryAt}
+		}
+		c.state = CircuitHalfOpen
+		c.probes = 1
+	case CircuitHalfOpen:
+		if c.probes >= cb.config.HalfOpenRequests {
+			cb.mu.Unlock()
+			return &CircuitOpenError{Group: group, RetryAt: cb.now()}
+		}
+		c.probes++
+	}
+
+	to := c.state
+	cb.mu.Unlock()
+
+	cb.notify(group, from, to)
+	return nil
+}
+
+// Record reports the outcome of a request previously admitted by Allow
+func (cb *CircuitBreaker) Record(group string, success bool) {
+	cb.mu.Lock()
+	c := cb.circuitFor(group)
+	from := c.state
+
+	if c.state == CircuitHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+
+	switch {
+	case success:
+		c.failures = 0
+		c.state = CircuitClosed
+	case c.state == CircuitHalfOpen:
+		c.state = CircuitOpen
+		c.openedAt = cb.now()
+	default:
+		c.failures++
+		if c.state == CircuitClosed && c.failures >= cb.config.FailureThreshold {
+			c.state = CircuitOpen
+			c.openedAt = cb.now()
+		}
+	}
+
+	to := c.state
+	cb.mu.Unlock()
+
+	cb.notify(group, from, to)
+}
+
+// release gives back a probe slot without recording an outcome, for requests
+// abandoned by the caller (e.g. a cancelled context)
+func (cb *CircuitBreaker) release(group string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if c, ok := cb.groups[group]; ok && c.state == CircuitHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
+
+// circuitFor returns the circuit for group; the caller must hold cb.mu
+func (cb *CircuitBreaker) circuitFor(group string) *circuit {
+	c, ok := cb.groups[group]
+	if !ok {
+		c = &circuit{}
+		cb.groups[group] = c
+	}
+	return c
+}
+
+func (cb *CircuitBreaker) notify(group string, from, to CircuitState) {
+	if from != to && cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(group, from, to)
+	}
+}
+
+// endpointGroup maps an API path to the group a circuit breaker tracks it
+// under: its first segment, so "/instances/abc/tags" belongs to "/instances"
+func endpointGroup(path string) string {
+	trimmed := strings.TrimPrefix(path, "/")
+	if i := strings.IndexByte(trimmed, '/'); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	return "/" + trimmed
+}
+
+// isCircuitFailure reports whether an HTTP outcome counts against the circuit
+func isCircuitFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// CircuitBreakerMiddleware rejects requests whose endpoint group has an open
+// circuit before the rest of the middleware chain (authentication included)
+// runs. WithCircuitBreaker registers it automatically; the breaker itself is
+// also consulted for every HTTP attempt.
+func CircuitBreakerMiddleware(cb *CircuitBreaker) RequestMiddleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(ctx *RequestContext) error {
+			path := ctx.Path
+			if ctx.Client != nil {
+				path = ctx.Client.relativePath(path)
+			}
+			group := endpointGroup(path)
+
+			// Only reject outright while open; half-open probes are admitted
+			// per attempt so they are counted exactly once
+			cb.mu.Lock()
+			c, ok := cb.groups[group]
+			var openErr error
+			if ok && c.state == CircuitOpen {
+				if retryAt := c.openedAt.Add(cb.config.Cooldown); cb.now().Before(retryAt) {
+					openErr = &CircuitOpenError{Group: group, RetryAt: retryAt}
+				}
+			}
+			cb.mu.Unlock()
+
+			if openErr != nil {
+				return openErr
+			}
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+// fakeClock is a settable time source for circuit breaker tests
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func newTestCircuitBreaker(config CircuitBreakerConfig) (*CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cb := NewCircuitBreaker(config)
+	cb.now = clock.Now
+	return cb, clock
+}
+
+func TestCircuitBreaker_StateMachine(t *testing.T) {
+	t.Run("trips after consecutive failures", func(t *testing.T) {
+		cb, _ := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 3})
+
+		for i := 0; i < 2; i++ {
+			if err := cb.Allow("/instances"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cb.Record("/instances", false)
+		}
+		if got := cb.State("/instances"); got != CircuitClosed {
+			t.Fatalf("expected closed below the threshold, got %s", got)
+		}
+
+		_ = cb.Allow("/instances")
+		cb.Record("/instances", false)
+		if got := cb.State("/instances"); got != CircuitOpen {
+			t.Fatalf("expected open at the threshold, got %s", got)
+		}
+
+		err := cb.Allow("/instances")
+		var openErr *CircuitOpenError
+		if !errors.As(err, &openErr) || !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected a CircuitOpenError, got %v", err)
+		}
+		if openErr.Group != "/instances" {
+			t.Errorf("expected group /instances, got %s", openErr.Group)
+		}
+	})
+
+	t.Run("success resets the failure count", func(t *testing.T) {
+		cb, _ := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2})
+
+		cb.Record("/volumes", false)
+		cb.Record("/volumes", true)
+		cb.Record("/volumes", false)
+		if got := cb.State("/volumes"); got != CircuitClosed {
+			t.Errorf("expected failures to be consecutive, got %s", got)
+		}
+	})
+
+	t.Run("half-open probe success closes", func(t *testing.T) {
+		cb, clock := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute})
+
+		cb.Record("/instances", false)
+		clock.Advance(time.Minute)
+		if got := cb.State("/instances"); got != CircuitHalfOpen {
+			t.Fatalf("expected half-open after the cooldown, got %s", got)
+		}
+
+		if err := cb.Allow("/instances"); err != nil {
+			t.Fatalf("expected the probe to be admitted, got %v", err)
+		}
+		if err := cb.Allow("/instances"); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("expected a second concurrent probe to be rejected, got %v", err)
+		}
+
+		cb.Record("/instances", true)
+		if got := cb.State("/instances"); got != CircuitClosed {
+			t.Errorf("expected closed after a successful probe, got %s", got)
+		}
+	})
+
+	t.Run("half-open probe failure reopens", func(t *testing.T) {
+		cb, clock := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute})
+
+		cb.Record("/instances", false)
+		clock.Advance(time.Minute)
+		if err := cb.Allow("/instances"); err != nil {
+			t.Fatalf("expected the probe to be admitted, got %v", err)
+		}
+		cb.Record("/instances", false)
+
+		if got := cb.State("/instances"); got != CircuitOpen {
+			t.Errorf("expected open after a failed probe, got %s", got)
+		}
+		if err := cb.Allow("/instances"); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("expected a fresh cooldown, got %v", err)
+		}
+	})
+
+	t.Run("groups are independent", func(t *testing.T) {
+		cb, _ := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+
+		cb.Record("/instances", false)
+		if err := cb.Allow("/volumes"); err != nil {
+			t.Errorf("expected /volumes to be unaffected, got %v", err)
+		}
+	})
+
+	t.Run("state changes are reported", func(t *testing.T) {
+		var transitions []string
+		cb, clock := newTestCircuitBreaker(CircuitBreakerConfig{
+			FailureThreshold: 1,
+			Cooldown:         time.Minute,
+			OnStateChange: func(group string, from, to CircuitState) {
+				transitions = append(transitions, group+":"+from.String()+"->"+to.String())
+			},
+		})
+
+		cb.Record("/clusters", false)
+		clock.Advance(time.Minute)
+		_ = cb.Allow("/clusters")
+		cb.Record("/clusters", true)
+
+		expected := []string{
+			"/clusters:closed->open",
+			"/clusters:open->half-open",
+			"/clusters:half-open->closed",
+		}
+		if len(transitions) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, transitions)
+		}
+		for i := range expected {
+			if transitions[i] != expected[i] {
+				t.Errorf("transition %d: expected %s, got %s", i, expected[i], transitions[i])
+			}
+		}
+	})
+}
+
+func TestEndpointGroup(t *testing.T) {
+	tests := map[string]string{
+		"/instances":           "/instances",
+		"/instances/abc":       "/instances",
+		"/instances/abc/tags":  "/instances",
+		"/instance-types":      "/instance-types",
+		"volumes":              "/volumes",
+		"/":                    "/",
+		"/container-types/xyz": "/container-types",
+	}
+	for path, want := range tests {
+		if got := endpointGroup(path); got != want {
+			t.Errorf("endpointGroup(%q) = %q, expected %q", path, got, want)
+		}
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("opens on server errors and fails fast", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/instances/inst_1", func(w http.ResponseWriter, _ *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			testutil.ErrorResponse(w, http.StatusBadGateway, "upstream down")
+		})
+
+		cb, _ := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2})
+		client := newRateLimitedTestClient(mockServer, WithCircuitBreaker(cb))
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.Instances.GetByID(ctx, "inst_1"); err == nil || errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("expected the server error, got %v", err)
+			}
+		}
+
+		_, err := client.Instances.GetByID(ctx, "inst_1")
+		if !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected ErrCircuitOpen, got %v", err)
+		}
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("expected the open circuit to skip the server, got %d calls", n)
+		}
+
+		// Other endpoint groups keep working
+		if _, err := client.Balance.Get(ctx); err != nil {
+			t.Errorf("expected /balance to be unaffected, got %v", err)
+		}
+	})
+
+	t.Run("client errors do not trip the circuit", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		mockServer.SetHandler(http.MethodGet, "/instances/missing", func(w http.ResponseWriter, _ *http.Request) {
+			testutil.ErrorResponse(w, http.StatusNotFound, "not found")
+		})
+
+		cb, _ := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+		client := newRateLimitedTestClient(mockServer, WithCircuitBreaker(cb))
+
+		for i := 0; i < 3; i++ {
+			if _, err := client.Instances.GetByID(ctx, "missing"); errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("expected 404s not to open the circuit")
+			}
+		}
+		if got := cb.State("/instances"); got != CircuitClosed {
+			t.Errorf("expected closed, got %s", got)
+		}
+	})
+
+	t.Run("transport errors count as failures", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		cb, _ := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+		client := newRateLimitedTestClient(mockServer, WithCircuitBreaker(cb))
+
+		// Authenticate first so only the API request hits the closed server
+		if _, err := client.Balance.Get(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mockServer.Close()
+
+		if _, err := client.Volumes.ListVolumes(ctx); err == nil {
+			t.Fatal("expected a transport error")
+		}
+		if got := cb.State("/volumes"); got != CircuitOpen {
+			t.Errorf("expected open after a transport error, got %s", got)
+		}
+	})
+
+	t.Run("retries stop once the circuit opens", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/balance", func(w http.ResponseWriter, _ *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			testutil.ErrorResponse(w, http.StatusServiceUnavailable, "unavailable")
+		})
+
+		cb, _ := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2})
+		client := newRateLimitedTestClient(mockServer,
+			WithCircuitBreaker(cb),
+			WithRetryPolicy(&RetryPolicy{MaxRetries: 5, InitialDelay: time.Millisecond}),
+		)
+
+		if _, err := client.Balance.Get(ctx); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected ErrCircuitOpen, got %v", err)
+		}
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("expected retries to stop at the threshold, got %d calls", n)
+		}
+	})
+
+	t.Run("open circuit fails before authentication", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		cb, _ := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+		cb.Record("/balance", false)
+
+		client := newRateLimitedTestClient(mockServer, WithCircuitBreaker(cb))
+		if _, err := client.Balance.Get(ctx); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected ErrCircuitOpen, got %v", err)
+		}
+		if client.Auth.token != nil {
+			t.Error("expected no token request while the circuit is open")
+		}
+	})
+
+	t.Run("half-open probe closes the circuit", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		cb, clock := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute})
+		cb.Record("/balance", false)
+		clock.Advance(time.Minute)
+
+		client := newRateLimitedTestClient(mockServer, WithCircuitBreaker(cb))
+		if _, err := client.Balance.Get(ctx); err != nil {
+			t.Fatalf("expected the probe to succeed, got %v", err)
+		}
+		if got := cb.State("/balance"); got != CircuitClosed {
+			t.Errorf("expected closed, got %s", got)
+		}
+	})
+}
@@ -20,9 +20,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 )
 
 const (
@@ -37,9 +40,57 @@ type Client struct {
 	AuthBearerToken string
 	UserAgent       string
 
+	// DefaultLocation is used by create requests that do not name a location.
+	// Defaults to FIN-03.
+	DefaultLocation string
+
+	// TokenSource supplies the access token for each request. It defaults to
+	// Auth, which uses the client credentials.
+	TokenSource TokenSource
+	// TokenCache, when set, persists the tokens Auth fetches between processes
+	TokenCache TokenCache
+
+	// HTTPClient sends the requests. NewClient builds it from Transport
+	// unless WithHTTPClient is given.
 	HTTPClient *http.Client
+	Transport  TransportConfig
 	Logger     Logger
 
+	// Timeout bounds each call through Do, including its retries; zero means
+	// no limit
+	Timeout time.Duration
+
+	// RetryPolicy controls transport-level retries in Do; nil disables them
+	RetryPolicy *RetryPolicy
+
+	// CircuitBreaker, when set, fails requests fast while an endpoint group is unhealthy
+	CircuitBreaker *CircuitBreaker
+
+	// Cache, when set, serves repeated catalog GETs from memory
+	Cache *ResponseCache
+
+	// Metrics, when set, receives request, retry and token measurements
+	Metrics MetricsCollector
+
+	// Tracer, when set, creates a span for every Do call
+	Tracer Tracer
+
+	rateLimiter *rateLimiter
+
+	// cacheIdentity scopes the client's ResponseCache entries to its credentials
+	cacheIdentity string
+
+	// slogger is Logger as a *slog.Logger, built once by NewClient
+	slogger *slog.Logger
+
+	// Set by WithProfile and WithConfigFile, and by a config enabling debug
+	loadConfig bool
+	profile    string
+	configFile string
+	debug      bool
+
+	transportSet bool
+
 	// Middleware management for all requests
 	Middleware *Middleware
 
@@ -57,6 +108,7 @@ type Client struct {
 	InstanceAvailability *InstanceAvailabilityService
 	ContainerTypes       *ContainerTypesService
 	Clusters             *ClusterService
+	Tags                 *TagService
 	LongTerm             *LongTermService
 	ContainerDeployments *ContainerDeploymentsService
 	ServerlessJobs       *ServerlessJobsService
@@ -65,40 +117,39 @@ type Client struct {
 type ClientOption func(*Client)
 
 func NewClient(options ...ClientOption) (*Client, error) {
-
-	client := &Client{
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: &http.Client{},
-		Logger:     &NoOpLogger{}, // Default: no logging
+	client, err := newTransportClient(options...)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, option := range options {
-		option(client)
-	}
+	client.Middleware = NewDefaultMiddlewareWithUserAgent(client.Logger, client.UserAgent)
 
-	// Enable debug logging via VERDA_DEBUG env var if no custom logger was set
-	if verdaDebug := os.Getenv("VERDA_DEBUG"); strings.ToLower(verdaDebug) == trueString {
-		if _, isNoOp := client.Logger.(*NoOpLogger); isNoOp {
-			client.Logger = NewStdLogger(true)
-		}
+	// The circuit breaker goes first so an open circuit fails before authentication
+	if client.CircuitBreaker != nil {
+		requestMiddleware, _ := client.Middleware.Snapshot()
+		client.Middleware.SetRequestMiddleware(append([]RequestMiddleware{CircuitBreakerMiddleware(client.CircuitBreaker)}, requestMiddleware...))
 	}
 
-	client.Middleware = NewDefaultMiddlewareWithUserAgent(client.Logger, client.UserAgent)
-
 	// Wire up debug middleware if VERDA_DEBUG is set
-	if verdaDebug := os.Getenv("VERDA_DEBUG"); strings.ToLower(verdaDebug) == trueString {
+	if client.debug {
 		client.Middleware.AddRequestMiddleware(DebugLoggingMiddleware(client.Logger))
 		client.Middleware.AddResponseMiddleware(DebugResponseLoggingMiddleware(client.Logger))
 	}
 
-	if client.ClientID == "" {
-		return nil, fmt.Errorf("client ID is required")
-	}
-	if client.ClientSecret == "" {
-		return nil, fmt.Errorf("client secret is required")
+	if client.TokenSource == nil {
+		if client.ClientID == "" {
+			return nil, fmt.Errorf("client ID is required")
+		}
+		if client.ClientSecret == "" {
+			return nil, fmt.Errorf("client secret is required")
+		}
 	}
 
 	client.Auth = &AuthService{client: client}
+	if client.TokenSource == nil {
+		client.TokenSource = client.Auth
+	}
+	client.cacheIdentity = responseCacheIdentity(client)
 	client.Balance = &BalanceService{client: client}
 	client.Instances = &InstanceService{client: client}
 	client.Volumes = &VolumeService{client: client}
@@ -111,6 +162,7 @@ func NewClient(options ...ClientOption) (*Client, error) {
 	client.InstanceAvailability = &InstanceAvailabilityService{client: client}
 	client.ContainerTypes = &ContainerTypesService{client: client}
 	client.Clusters = &ClusterService{client: client}
+	client.Tags = &TagService{client: client}
 	client.LongTerm = &LongTermService{client: client}
 	client.ContainerDeployments = &ContainerDeploymentsService{client: client}
 	client.ServerlessJobs = &ServerlessJobsService{client: client}
@@ -118,6 +170,47 @@ func NewClient(options ...ClientOption) (*Client, error) {
 	return client, nil
 }
 
+// newTransportClient applies options and config and builds the HTTP client,
+// leaving out middleware and services. Token sources use it to share the
+// transport, rate limits and circuit breaker without a full client.
+func newTransportClient(options ...ClientOption) (*Client, error) {
+	client := &Client{
+		BaseURL: DefaultBaseURL,
+		Timeout: DefaultTimeout,
+		Logger:  &NoOpLogger{}, // Default: no logging
+	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	if client.loadConfig {
+		cfg, err := LoadConfig(options...)
+		if err != nil {
+			return nil, err
+		}
+		client.applyConfig(cfg)
+	}
+
+	if client.HTTPClient == nil {
+		client.HTTPClient = client.Transport.NewHTTPClient()
+	} else if client.transportSet {
+		return nil, errTransportWithHTTPClient
+	}
+
+	// Enable debug logging via VERDA_DEBUG env var if no custom logger was set
+	debug := client.debug || strings.ToLower(os.Getenv("VERDA_DEBUG")) == trueString
+	client.debug = debug
+	if debug {
+		if _, isNoOp := client.Logger.(*NoOpLogger); isNoOp {
+			client.Logger = NewStdLogger(true)
+		}
+	}
+	client.slogger = slogFor(client.Logger)
+
+	return client, nil
+}
+
 func (c *Client) AddRequestMiddleware(middleware RequestMiddleware) {
 	c.Middleware.AddRequestMiddleware(middleware)
 }
@@ -142,6 +235,18 @@ func (c *Client) ClearResponseMiddleware() {
 	c.Middleware.ClearResponseMiddleware()
 }
 
+func (c *Client) AddExchangeMiddleware(middleware ExchangeMiddleware) {
+	c.Middleware.AddExchangeMiddleware(middleware)
+}
+
+func (c *Client) SetExchangeMiddleware(middleware []ExchangeMiddleware) {
+	c.Middleware.SetExchangeMiddleware(middleware)
+}
+
+func (c *Client) ClearExchangeMiddleware() {
+	c.Middleware.ClearExchangeMiddleware()
+}
+
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) {
 		c.BaseURL = baseURL
@@ -161,6 +266,9 @@ func WithClientSecret(clientSecret string) ClientOption {
 	}
 }
 
+// WithAuthBearerToken sends token as the Authorization header of the OAuth
+// token requests. API requests still use the client credentials; see
+// WithStaticToken to skip them.
 func WithAuthBearerToken(token string) ClientOption {
 	return func(c *Client) {
 		c.AuthBearerToken = token
@@ -191,6 +299,14 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// defaultLocation returns the location for create requests that do not name one
+func (c *Client) defaultLocation() string {
+	if c.DefaultLocation != "" {
+		return c.DefaultLocation
+	}
+	return LocationFIN03
+}
+
 func (c *Client) WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
 		c.HTTPClient = httpClient
@@ -215,66 +331,66 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Re
 
 // Do executes the request through middleware and returns the parsed response
 func (c *Client) Do(req *http.Request, result any) (*Response, error) {
-	// Snapshot middleware to avoid race conditions
-	requestMiddleware, responseMiddleware := c.Middleware.Snapshot()
+	timeout := c.Timeout
+	if options := requestOptionsFrom(req.Context()); options.timeoutSet {
+		timeout = options.timeout
+	}
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	req, endCapture := c.startCapture(req)
+	req, endSpan := c.startSpan(req)
+	resp, err := c.do(req, result)
+	endSpan(resp, err)
+	endCapture(resp)
+	return resp, err
+}
 
-	reqCtx := &RequestContext{
-		Method:  req.Method,
-		Path:    req.URL.Path,
-		Body:    nil,
-		Headers: req.Header.Clone(),
-		Query:   req.URL.Query(),
-		Client:  c,
-		Request: req,
-	}
+func (c *Client) do(req *http.Request, result any) (*Response, error) {
+	applyRequestOptions(req, requestOptionsFrom(req.Context()))
 
-	requestHandler := c.buildRequestChain(requestMiddleware)
-	if err := requestHandler(reqCtx); err != nil {
-		return nil, fmt.Errorf("request middleware failed: %w", err)
+	// Set the idempotency key before middleware and retries so every attempt
+	// of this call carries the same key
+	if key := idempotencyKeyFor(req.Context(), req.Method, req.Header); key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
 	}
 
-	// Apply middleware-modified headers back to the request
-	for name, values := range reqCtx.Headers {
-		req.Header.Del(name)
-		for _, value := range values {
-			req.Header.Add(name, value)
-		}
+	ex, err := c.newExchange(req, result)
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := c.HTTPClient.Do(req) //nolint:gosec // G704: SDK client - URL is configured by caller
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	err = c.buildExchangeChain(c.Middleware.ExchangeSnapshot())(ex)
+	if ex.Response == nil {
+		return nil, err
 	}
+	return &Response{Response: ex.Response}, err
+}
 
-	wrappedResp := &Response{Response: resp}
+// exchange sends ex through the request and response middleware chains
+func (c *Client) exchange(ex *Exchange) error {
+	// Snapshot middleware to avoid race conditions
+	requestMiddleware, responseMiddleware := c.callMiddleware(ex.Context())
 
-	bodyBytes, readErr := io.ReadAll(resp.Body)
-	_ = resp.Body.Close()
-	if readErr != nil {
-		return wrappedResp, fmt.Errorf("failed to read response body: %w", readErr)
+	reqCtx, resp, bodyBytes, err := c.sendExchange(ex, requestMiddleware)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized &&
+		c.invalidateToken(resp.Request.Header.Get("Authorization")) {
+		// The token was rejected: replay once with a fresh one
+		reqCtx, resp, bodyBytes, err = c.sendExchange(ex, requestMiddleware)
+	}
+	ex.Response, ex.ResponseBytes = resp, bodyBytes
+	if resp == nil || err != nil {
+		return err
 	}
 
 	var parseErr error
-	if result != nil {
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			var apiError APIError
-			if err := json.Unmarshal(bodyBytes, &apiError); err != nil {
-				parseErr = &APIError{
-					StatusCode: resp.StatusCode,
-					Message:    string(bodyBytes),
-				}
-			} else {
-				apiError.StatusCode = resp.StatusCode
-				parseErr = &apiError
-			}
-		} else {
-			trimmed := bytes.TrimSpace(bodyBytes)
-			if len(trimmed) > 0 && result != nil {
-				if err := json.Unmarshal(trimmed, result); err != nil {
-					parseErr = fmt.Errorf("failed to unmarshal response: %w", err)
-				}
-			}
-		}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		parseErr = newAPIError(resp, bodyBytes)
+	} else {
+		parseErr = decodeResponse(bodyBytes, ex.Result)
 	}
 
 	respCtx := &ResponseContext{
@@ -287,14 +403,41 @@ func (c *Client) Do(req *http.Request, result any) (*Response, error) {
 
 	responseHandler := c.buildResponseChain(responseMiddleware)
 	if middlewareErr := responseHandler(respCtx); middlewareErr != nil {
-		return wrappedResp, fmt.Errorf("response middleware failed: %w", middlewareErr)
+		return fmt.Errorf("response middleware failed: %w", middlewareErr)
+	}
+
+	return respCtx.Error
+}
+
+// sendExchange runs the request middleware and sends one copy of ex's request
+func (c *Client) sendExchange(ex *Exchange, requestMiddleware []RequestMiddleware) (*RequestContext, *http.Response, []byte, error) {
+	req := ex.outgoingRequest()
+	reqCtx := &RequestContext{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Body:    ex.RequestBody,
+		Headers: req.Header.Clone(),
+		Query:   req.URL.Query(),
+		Client:  c,
+		Request: req,
 	}
 
-	if respCtx.Error != nil {
-		return wrappedResp, respCtx.Error
+	requestHandler := c.buildRequestChain(requestMiddleware)
+	if err := requestHandler(reqCtx); err != nil {
+		return reqCtx, nil, nil, fmt.Errorf("request middleware failed: %w", err)
 	}
 
-	return wrappedResp, nil
+	// Apply middleware-modified headers back to the request
+	applyHeaders(req, reqCtx.Headers)
+
+	if resp, bodyBytes, cached := c.cachedResponse(req); cached {
+		return reqCtx, resp, bodyBytes, nil
+	}
+	resp, bodyBytes, err := c.send(req)
+	if err == nil {
+		c.updateCache(req, resp, bodyBytes)
+	}
+	return reqCtx, resp, bodyBytes, err
 }
 
 func (c *Client) buildRequestChain(requestMiddleware []RequestMiddleware) RequestHandler {
@@ -325,39 +468,28 @@ func (c *Client) buildResponseChain(responseMiddleware []ResponseMiddleware) Res
 	return handler
 }
 
-// makeRequest is kept for endpoints with plain text responses that don't fit the normal JSON flow.
-// Prefer NewRequest() + Do() for new code to get full middleware support.
-func (c *Client) makeRequest(ctx context.Context, method, path string, body any) (*http.Response, error) {
-	url := c.BaseURL + path
-
-	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		reqBody = bytes.NewBuffer(jsonBody)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+// relativePath strips the base URL's path (e.g. "/v1") from a request path so
+// that client-side limits see API paths such as "/instances"
+func (c *Client) relativePath(path string) string {
+	base, err := url.Parse(c.BaseURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return path
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	bearerToken, err := c.Auth.GetBearerToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get authentication token: %w", err)
+	prefix := strings.TrimSuffix(base.Path, "/")
+	if prefix == "" || !strings.HasPrefix(path, prefix) {
+		return path
 	}
-	req.Header.Set("Authorization", bearerToken)
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	rest := path[len(prefix):]
+	switch {
+	case rest == "":
+		return "/"
+	case strings.HasPrefix(rest, "/"):
+		return rest
+	default:
+		return path
 	}
-
-	return resp, nil
 }
 
 func (c *Client) handleResponse(resp *http.Response, result any) error {
@@ -371,15 +503,7 @@ func (c *Client) handleResponse(resp *http.Response, result any) error {
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var apiError APIError
-		if err := json.Unmarshal(body, &apiError); err != nil {
-			return &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    string(body),
-			}
-		}
-		apiError.StatusCode = resp.StatusCode
-		return &apiError
+		return newAPIError(resp, body)
 	}
 
 	trimmed := bytes.TrimSpace(body)
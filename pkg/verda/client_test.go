@@ -164,40 +164,6 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
-func TestClientMakeRequest(t *testing.T) {
-	mockServer := testutil.NewMockServer()
-	defer mockServer.Close()
-
-	client := NewTestClient(mockServer)
-
-	// Test successful request
-	t.Run("successful request", func(t *testing.T) {
-		resp, err := client.makeRequest(context.Background(), http.MethodGet, "/balance", nil)
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
-		}
-		if resp != nil {
-			_ = resp.Body.Close() //nolint:errcheck // Test code
-		} else {
-			t.Error("expected response, got nil")
-		}
-	})
-
-	// Test request with body
-	t.Run("request with body", func(t *testing.T) {
-		requestBody := map[string]string{"test": "data"}
-		resp, err := client.makeRequest(context.Background(), http.MethodPost, "/instances", requestBody)
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
-		}
-		if resp != nil {
-			_ = resp.Body.Close() //nolint:errcheck // Test code
-		} else {
-			t.Error("expected response, got nil")
-		}
-	})
-}
-
 func TestClientHandleResponse(t *testing.T) {
 	mockServer := testutil.NewMockServer()
 	defer mockServer.Close()
@@ -205,7 +171,7 @@ func TestClientHandleResponse(t *testing.T) {
 	client := NewTestClient(mockServer)
 
 	t.Run("successful response parsing", func(t *testing.T) {
-		resp, err := client.makeRequest(context.Background(), http.MethodGet, "/balance", nil)
+		resp, err := client.HTTPClient.Get(mockServer.URL() + "/balance")
 		if err != nil {
 			t.Fatalf("unexpected error making request: %v", err)
 		}
@@ -230,7 +196,7 @@ func TestClientHandleResponse(t *testing.T) {
 			testutil.ErrorResponse(w, http.StatusBadRequest, "Test error message")
 		})
 
-		resp, err := client.makeRequest(context.Background(), http.MethodGet, "/error-test", nil)
+		resp, err := client.HTTPClient.Get(mockServer.URL() + "/error-test")
 		if err != nil {
 			t.Fatalf("unexpected error making request: %v", err)
 		}
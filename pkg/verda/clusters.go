@@ -17,6 +17,7 @@ package verda
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 )
 
@@ -32,6 +33,21 @@ func (s *ClusterService) Get(ctx context.Context) ([]Cluster, error) {
 	return clusters, nil
 }
 
+// List returns the clusters matching opts; nil opts lists all of them
+func (s *ClusterService) List(ctx context.Context, opts *ClusterListOptions) ([]Cluster, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// All iterates over the clusters matching opts, which are fetched together
+// in one unpaginated request
+func (s *ClusterService) All(ctx context.Context, opts *ClusterListOptions) iter.Seq2[Cluster, error] {
+	o := ClusterListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return listSeq(ctx, s.Get, o.matches)
+}
+
 func (s *ClusterService) GetByID(ctx context.Context, id string) (*Cluster, error) {
 	path := fmt.Sprintf("/clusters/%s", id)
 
@@ -48,7 +64,7 @@ func (s *ClusterService) Create(ctx context.Context, req CreateClusterRequest) (
 	}
 
 	if req.LocationCode == "" {
-		req.LocationCode = LocationFIN03
+		req.LocationCode = s.client.defaultLocation()
 	}
 
 	response, _, err := postRequest[CreateClusterResponse](ctx, s.client, "/clusters", req)
@@ -139,6 +155,26 @@ func (s *ClusterService) GetImages(ctx context.Context) ([]ClusterImage, error)
 	return images, nil
 }
 
+// WaitForStatus polls the cluster until both the cluster and every worker node
+// report status. A cluster or node in a terminal status (StatusError,
+// StatusNoCapacity, StatusDiscontinued) fails the wait with a *TerminalStatusError.
+func (s *ClusterService) WaitForStatus(ctx context.Context, id, status string, opts *WaitOptions) (*Cluster, error) {
+	return waitForResource(ctx, id, status, instanceTerminalStatuses, opts, s.GetByID,
+		func(cluster *Cluster) (string, bool) {
+			if cluster.Status != status {
+				return cluster.Status, false
+			}
+			// Report the first unsettled node so its status is visible to progress
+			// callbacks and terminal detection
+			for _, node := range cluster.WorkerNodes {
+				if node.Status != status {
+					return node.Status, false
+				}
+			}
+			return cluster.Status, true
+		})
+}
+
 // AddTag adds a single key-value tag to a cluster. Maximum 10 tags per cluster.
 // Leave req.Value empty for a freeform tag.
 //
@@ -152,3 +188,14 @@ func (s *ClusterService) AddTag(ctx context.Context, clusterID string, req TagRe
 func (s *ClusterService) DeleteTag(ctx context.Context, clusterID, key string) error {
 	return deleteResourceTag(ctx, s.client, "/clusters", clusterID, key)
 }
+
+// SetTags makes a cluster carry exactly the desired tags; see InstanceService.SetTags
+func (s *ClusterService) SetTags(ctx context.Context, clusterID string, desired map[string]string) (*TagChanges, error) {
+	return setResourceTags(ctx, s.client, "/clusters", clusterID, desired, func(ctx context.Context) ([]Tag, error) {
+		cluster, err := s.GetByID(ctx, clusterID)
+		if err != nil {
+			return nil, err
+		}
+		return cluster.Tags, nil
+	})
+}
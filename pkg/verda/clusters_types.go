@@ -179,3 +179,24 @@ func (r ClusterSharedVolumeSpec) Validate() error {
 		validation.Field(&r.Size, validation.Required, validation.Min(1)),
 	)
 }
+
+// ClusterListOptions filters ClusterService.List and All. Zero fields do not
+// filter.
+type ClusterListOptions struct {
+	Statuses       []string
+	Location       string
+	ClusterType    string
+	Tags           TagSelector
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	HostnamePrefix string
+}
+
+func (o *ClusterListOptions) matches(c *Cluster) bool {
+	return matchesStatus(c.Status, o.Statuses) &&
+		(o.Location == "" || c.Location == o.Location) &&
+		(o.ClusterType == "" || c.ClusterType == o.ClusterType) &&
+		matchesTags(o.Tags, c.Tags) &&
+		createdBetween(c.CreatedAt, o.CreatedAfter, o.CreatedBefore) &&
+		matchesPrefix(c.Hostname, o.HostnamePrefix)
+}
@@ -0,0 +1,445 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultProfile is the profile used when none is selected
+const DefaultProfile = "default"
+
+// Environment variables read by LoadConfig. Every setting can also be given
+// as VERDA_ followed by its upper-cased name, e.g. VERDA_MAX_RETRIES.
+const (
+	EnvProfile    = "VERDA_PROFILE"
+	EnvConfigFile = "VERDA_CONFIG_FILE"
+)
+
+// Names of the settings resolved by LoadConfig, as used in the config file
+// and as keys of Config.Sources
+const (
+	SettingClientID        = "client_id"
+	SettingClientSecret    = "client_secret"
+	SettingBaseURL         = "base_url"
+	SettingUserAgent       = "user_agent"
+	SettingDebug           = "debug"
+	SettingMaxRetries      = "max_retries"
+	SettingRetryMaxDelay   = "retry_max_delay"
+	SettingTimeout         = "timeout"
+	SettingDefaultLocation = "default_location"
+)
+
+var configSettings = []string{
+	SettingClientID,
+	SettingClientSecret,
+	SettingBaseURL,
+	SettingUserAgent,
+	SettingDebug,
+	SettingMaxRetries,
+	SettingRetryMaxDelay,
+	SettingTimeout,
+	SettingDefaultLocation,
+}
+
+// ConfigSourceKind is the layer of the configuration chain a value came from
+type ConfigSourceKind int
+
+const (
+	SourceDefault ConfigSourceKind = iota
+	SourceOption
+	SourceEnv
+	SourceProfile
+)
+
+// ConfigSource says where a configuration value came from
+type ConfigSource struct {
+	Kind ConfigSourceKind
+	// Name is the environment variable, or the profile for SourceProfile
+	Name string
+	// File is the config file for SourceProfile
+	File string
+}
+
+func (s ConfigSource) String() string {
+	switch s.Kind {
+	case SourceOption:
+		return "client option"
+	case SourceEnv:
+		return "environment variable " + s.Name
+	case SourceProfile:
+		return fmt.Sprintf("profile %q in %s", s.Name, s.File)
+	default:
+		return "default"
+	}
+}
+
+// Config is the result of resolving the configuration chain: explicit client
+// options first, then environment variables, then the selected profile of
+// the config file.
+type Config struct {
+	// Profile is the selected profile and File the config file it was read
+	// from; File is empty when there is no config file
+	Profile string
+	File    string
+
+	ClientID     string
+	ClientSecret string
+	BaseURL      string
+	UserAgent    string
+	Debug        bool
+	Retry        *RetryPolicy
+	// Timeout bounds each HTTP attempt, like WithRequestTimeout
+	Timeout         time.Duration
+	DefaultLocation string
+
+	// Sources maps each setting that was found to where it came from;
+	// settings missing from it kept their default
+	Sources map[string]ConfigSource
+}
+
+// Source returns where setting came from
+func (c *Config) Source(setting string) ConfigSource {
+	return c.Sources[setting]
+}
+
+// WithProfile makes NewClient resolve its settings through LoadConfig, using
+// the named profile of the config file
+func WithProfile(name string) ClientOption {
+	return func(c *Client) {
+		c.loadConfig = true
+		c.profile = name
+	}
+}
+
+// WithConfigFile makes LoadConfig read path instead of the default config file
+func WithConfigFile(path string) ClientOption {
+	return func(c *Client) {
+		c.loadConfig = true
+		c.configFile = path
+	}
+}
+
+// WithConfig applies a resolved Config to the client
+func WithConfig(cfg *Config) ClientOption {
+	return func(c *Client) {
+		c.applyConfig(cfg)
+	}
+}
+
+// WithDefaultLocation sets the location used by create requests that do not
+// name one
+func WithDefaultLocation(locationCode string) ClientOption {
+	return func(c *Client) {
+		c.DefaultLocation = locationCode
+	}
+}
+
+// DefaultConfigFile returns the verda/config file under the user config
+// directory, e.g. ~/.config/verda/config on Linux
+func DefaultConfigFile() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config"), nil
+}
+
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user config directory: %w", err)
+	}
+	return filepath.Join(dir, "verda"), nil
+}
+
+// LoadConfig resolves the client settings without creating a client. Each
+// setting is taken from the first of these that has it:
+//
+//  1. options, such as WithClientID or WithBaseURL
+//  2. the VERDA_<SETTING> environment variable, e.g. VERDA_CLIENT_ID
+//  3. the selected profile of the config file
+//
+// The profile is the one given to WithProfile, else VERDA_PROFILE, else
+// "default". The config file is the one given to WithConfigFile, else
+// VERDA_CONFIG_FILE, else DefaultConfigFile. A missing file is only an error
+// when a profile was selected explicitly.
+func LoadConfig(options ...ClientOption) (*Config, error) {
+	explicit := &Client{}
+	for _, option := range options {
+		option(explicit)
+	}
+
+	cfg := &Config{
+		Profile: explicit.profile,
+		BaseURL: DefaultBaseURL,
+		Sources: map[string]ConfigSource{},
+	}
+	profileRequired := cfg.Profile != ""
+	if cfg.Profile == "" {
+		cfg.Profile = os.Getenv(EnvProfile)
+		profileRequired = cfg.Profile != ""
+	}
+	if cfg.Profile == "" {
+		cfg.Profile = DefaultProfile
+	}
+
+	file := explicit.configFile
+	if file == "" {
+		file = os.Getenv(EnvConfigFile)
+	}
+	if file == "" {
+		var err error
+		if file, err = DefaultConfigFile(); err != nil && profileRequired {
+			return nil, err
+		}
+	}
+
+	var profile map[string]string
+	if file != "" {
+		profiles, err := readConfigFile(file)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && !profileRequired:
+		case err != nil:
+			return nil, err
+		default:
+			cfg.File = file
+			var ok bool
+			if profile, ok = profiles[cfg.Profile]; !ok && profileRequired {
+				return nil, fmt.Errorf("profile %q not found in %s", cfg.Profile, file)
+			}
+		}
+	}
+
+	// Retry settings from the environment or the profile adjust the default policy
+	var retry *RetryPolicy
+	for _, setting := range configSettings {
+		if cfg.setFromOption(setting, explicit) {
+			continue
+		}
+
+		value, source := "", ConfigSource{}
+		if env := "VERDA_" + strings.ToUpper(setting); os.Getenv(env) != "" {
+			value, source = os.Getenv(env), ConfigSource{Kind: SourceEnv, Name: env}
+		} else if v, ok := profile[setting]; ok {
+			value, source = v, ConfigSource{Kind: SourceProfile, Name: cfg.Profile, File: cfg.File}
+		} else {
+			continue
+		}
+
+		if setting == SettingMaxRetries || setting == SettingRetryMaxDelay {
+			if retry == nil {
+				retry = DefaultRetryPolicy()
+			}
+		}
+		if err := cfg.set(setting, value, retry); err != nil {
+			return nil, fmt.Errorf("invalid %s from %s: %w", setting, source, err)
+		}
+		cfg.Sources[setting] = source
+	}
+
+	// max_retries = 0 disables retries
+	if explicit.RetryPolicy == nil && retry != nil && retry.MaxRetries > 0 {
+		cfg.Retry = retry
+	}
+	return cfg, nil
+}
+
+// setFromOption takes setting from the client options, if they set it
+func (c *Config) setFromOption(setting string, explicit *Client) bool {
+	switch setting {
+	case SettingClientID:
+		c.ClientID = explicit.ClientID
+	case SettingClientSecret:
+		c.ClientSecret = explicit.ClientSecret
+	case SettingBaseURL:
+		if explicit.BaseURL == "" {
+			return false
+		}
+		c.BaseURL = explicit.BaseURL
+	case SettingUserAgent:
+		c.UserAgent = explicit.UserAgent
+	case SettingDebug:
+		logger, ok := explicit.Logger.(*StdLogger)
+		if !ok {
+			return false
+		}
+		c.Debug = logger.debugEnabled
+	case SettingMaxRetries, SettingRetryMaxDelay:
+		if explicit.RetryPolicy == nil {
+			return false
+		}
+		c.Retry = explicit.RetryPolicy
+	case SettingTimeout:
+		switch {
+		case explicit.HTTPClient != nil:
+			c.Timeout = explicit.HTTPClient.Timeout
+		case explicit.Transport.RequestTimeout != 0:
+			c.Timeout = explicit.Transport.requestTimeout()
+		default:
+			return false
+		}
+	case SettingDefaultLocation:
+		c.DefaultLocation = explicit.DefaultLocation
+	}
+
+	if c.isZero(setting) {
+		return false
+	}
+	c.Sources[setting] = ConfigSource{Kind: SourceOption}
+	return true
+}
+
+func (c *Config) isZero(setting string) bool {
+	switch setting {
+	case SettingClientID:
+		return c.ClientID == ""
+	case SettingClientSecret:
+		return c.ClientSecret == ""
+	case SettingUserAgent:
+		return c.UserAgent == ""
+	case SettingDefaultLocation:
+		return c.DefaultLocation == ""
+	default:
+		return false
+	}
+}
+
+// set parses value into setting
+func (c *Config) set(setting, value string, retry *RetryPolicy) error {
+	var err error
+	switch setting {
+	case SettingClientID:
+		c.ClientID = value
+	case SettingClientSecret:
+		c.ClientSecret = value
+	case SettingBaseURL:
+		c.BaseURL = strings.TrimSuffix(value, "/")
+	case SettingUserAgent:
+		c.UserAgent = value
+	case SettingDebug:
+		c.Debug, err = strconv.ParseBool(value)
+	case SettingMaxRetries:
+		retry.MaxRetries, err = strconv.Atoi(value)
+		if err == nil && retry.MaxRetries < 0 {
+			err = errors.New("must not be negative")
+		}
+	case SettingRetryMaxDelay:
+		retry.MaxDelay, err = time.ParseDuration(value)
+	case SettingTimeout:
+		c.Timeout, err = time.ParseDuration(value)
+	case SettingDefaultLocation:
+		c.DefaultLocation = value
+	}
+	return err
+}
+
+// readConfigFile parses an INI-style file of [profile] sections holding
+// "setting = value" lines. Lines starting with # or ; are comments. Unknown
+// settings are an error, as is a client_secret in a file other users can read.
+func readConfigFile(path string) (map[string]map[string]string, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: the config file path is chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	profiles := map[string]map[string]string{}
+	var section map[string]string
+	var sectionName string
+	hasSecret := false
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			sectionName = strings.TrimSpace(text[1 : len(text)-1])
+			if profiles[sectionName] == nil {
+				profiles[sectionName] = map[string]string{}
+			}
+			section = profiles[sectionName]
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			if !ok {
+				return nil, fmt.Errorf("%s:%d: expected setting = value", path, line)
+			}
+			if section == nil {
+				return nil, fmt.Errorf("%s:%d: setting outside of a [profile] section", path, line)
+			}
+			key = strings.TrimSpace(key)
+			if !slices.Contains(configSettings, key) {
+				return nil, fmt.Errorf("%s:%d: unknown setting %q in profile %q", path, line, key, sectionName)
+			}
+			section[key] = strings.Trim(strings.TrimSpace(value), `"`)
+			hasSecret = hasSecret || key == SettingClientSecret
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Like ssh and the cloud CLIs, refuse secrets other users can read
+	if hasSecret {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if configFileExposed(info) {
+			return nil, fmt.Errorf("%s holds a client_secret but other users can read it (mode %#o); run chmod 600 %s", path, info.Mode().Perm(), path)
+		}
+	}
+	return profiles, nil
+}
+
+// applyConfig copies the resolved settings onto the client
+func (c *Client) applyConfig(cfg *Config) {
+	c.ClientID = cfg.ClientID
+	c.ClientSecret = cfg.ClientSecret
+	c.BaseURL = cfg.BaseURL
+	c.UserAgent = cfg.UserAgent
+	c.DefaultLocation = cfg.DefaultLocation
+
+	if cfg.Debug {
+		c.debug = true
+		if _, isNoOp := c.Logger.(*NoOpLogger); isNoOp || c.Logger == nil {
+			c.Logger = NewStdLogger(true)
+		}
+	}
+	if cfg.Source(SettingMaxRetries).Kind != SourceDefault || cfg.Source(SettingRetryMaxDelay).Kind != SourceDefault {
+		c.RetryPolicy = cfg.Retry
+	}
+	if cfg.Timeout > 0 {
+		if c.HTTPClient == nil {
+			c.Transport.RequestTimeout = cfg.Timeout
+		} else if c.HTTPClient.Timeout != cfg.Timeout {
+			// Copy rather than modify the HTTP client, which may be shared
+			httpClient := *c.HTTPClient
+			httpClient.Timeout = cfg.Timeout
+			c.HTTPClient = &httpClient
+		}
+	}
+}
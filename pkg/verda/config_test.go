@@ -0,0 +1,227 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testConfigFile = `# Verda CLI profiles
+[default]
+client_id = default-id
+client_secret = default-secret
+
+[staging]
+client_id = staging-id
+client_secret = "staging-secret"
+base_url = https://api-staging.verda.com/v1
+user_agent = deploy-bot/1.0
+debug = true
+max_retries = 5
+retry_max_delay = 10s
+timeout = 45s
+default_location = ICE-01
+
+[broken]
+timeout = soon
+`
+
+// isolateConfig clears the environment the config chain reads and points it
+// at a temporary config file
+func isolateConfig(t *testing.T, contents string) string {
+	t.Helper()
+	for _, setting := range configSettings {
+		t.Setenv("VERDA_"+strings.ToUpper(setting), "")
+	}
+	t.Setenv(EnvProfile, "")
+
+	path := filepath.Join(t.TempDir(), "config")
+	if contents != "" {
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+	}
+	t.Setenv(EnvConfigFile, path)
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("default profile", func(t *testing.T) {
+		path := isolateConfig(t, testConfigFile)
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Profile != DefaultProfile || cfg.ClientID != "default-id" || cfg.BaseURL != DefaultBaseURL {
+			t.Errorf("unexpected config %+v", cfg)
+		}
+		want := ConfigSource{Kind: SourceProfile, Name: DefaultProfile, File: path}
+		if got := cfg.Source(SettingClientID); got != want {
+			t.Errorf("expected client_id from %v, got %v", want, got)
+		}
+		if got := cfg.Source(SettingBaseURL); got.Kind != SourceDefault {
+			t.Errorf("expected default base_url, got %v", got)
+		}
+	})
+
+	t.Run("named profile", func(t *testing.T) {
+		isolateConfig(t, testConfigFile)
+		cfg, err := LoadConfig(WithProfile("staging"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.ClientSecret != "staging-secret" || cfg.BaseURL != "https://api-staging.verda.com/v1" ||
+			cfg.UserAgent != "deploy-bot/1.0" || !cfg.Debug || cfg.Timeout != 45*time.Second || cfg.DefaultLocation != "ICE-01" {
+			t.Errorf("unexpected config %+v", cfg)
+		}
+		if cfg.Retry == nil || cfg.Retry.MaxRetries != 5 || cfg.Retry.MaxDelay != 10*time.Second {
+			t.Errorf("unexpected retry policy %+v", cfg.Retry)
+		}
+	})
+
+	t.Run("profile from environment", func(t *testing.T) {
+		isolateConfig(t, testConfigFile)
+		t.Setenv(EnvProfile, "staging")
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Profile != "staging" || cfg.ClientID != "staging-id" {
+			t.Errorf("unexpected config %+v", cfg)
+		}
+	})
+
+	t.Run("options override environment override profile", func(t *testing.T) {
+		isolateConfig(t, testConfigFile)
+		t.Setenv("VERDA_CLIENT_ID", "env-id")
+		t.Setenv("VERDA_BASE_URL", "https://env.example.com/v1")
+		cfg, err := LoadConfig(WithProfile("staging"), WithBaseURL("https://option.example.com/v1"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tests := []struct {
+			setting string
+			value   string
+			source  ConfigSourceKind
+		}{
+			{SettingBaseURL, cfg.BaseURL, SourceOption},
+			{SettingClientID, cfg.ClientID, SourceEnv},
+			{SettingClientSecret, cfg.ClientSecret, SourceProfile},
+		}
+		for _, tt := range tests {
+			if got := cfg.Source(tt.setting); got.Kind != tt.source {
+				t.Errorf("%s = %q: expected source %d, got %v", tt.setting, tt.value, tt.source, got)
+			}
+		}
+		if cfg.BaseURL != "https://option.example.com/v1" || cfg.ClientID != "env-id" {
+			t.Errorf("unexpected config %+v", cfg)
+		}
+		if got := cfg.Source(SettingClientID).String(); got != "environment variable VERDA_CLIENT_ID" {
+			t.Errorf("unexpected source description %q", got)
+		}
+	})
+
+	t.Run("missing file is fine without a profile", func(t *testing.T) {
+		isolateConfig(t, "")
+		t.Setenv("VERDA_CLIENT_ID", "env-id")
+		cfg, err := LoadConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.File != "" || cfg.ClientID != "env-id" {
+			t.Errorf("unexpected config %+v", cfg)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		isolateConfig(t, testConfigFile)
+		tests := []struct {
+			name    string
+			options []ClientOption
+			want    string
+		}{
+			{"unknown profile", []ClientOption{WithProfile("prod")}, `profile "prod" not found`},
+			{"invalid value", []ClientOption{WithProfile("broken")}, "invalid timeout"},
+			{"missing file", []ClientOption{WithProfile("staging"), WithConfigFile(filepath.Join(t.TempDir(), "nope"))}, "failed to read config file"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := LoadConfig(tt.options...)
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("expected error containing %q, got %v", tt.want, err)
+				}
+			})
+		}
+	})
+
+	t.Run("unknown settings are named", func(t *testing.T) {
+		isolateConfig(t, "[default]\nclient_id = id\nclient_secert = typo\n")
+		_, err := LoadConfig()
+		if err == nil || !strings.Contains(err.Error(), `:3: unknown setting "client_secert" in profile "default"`) {
+			t.Errorf("expected the unknown setting to be named, got %v", err)
+		}
+	})
+
+	t.Run("secrets readable by other users are refused", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file modes do not describe access on windows")
+		}
+		path := isolateConfig(t, testConfigFile)
+		if err := os.Chmod(path, 0o644); err != nil {
+			t.Fatalf("failed to chmod config: %v", err)
+		}
+		if _, err := LoadConfig(); err == nil || !strings.Contains(err.Error(), "chmod 600") {
+			t.Errorf("expected the readable secret to be refused, got %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte("[default]\nbase_url = https://api.example.com/v1\n"), 0o644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if _, err := LoadConfig(); err != nil {
+			t.Errorf("expected a file without secrets to be readable by others, got %v", err)
+		}
+	})
+}
+
+func TestNewClientWithProfile(t *testing.T) {
+	isolateConfig(t, testConfigFile)
+	shared := &http.Client{}
+
+	client, err := NewClient(WithProfile("staging"), WithHTTPClient(shared), WithLogger(&NoOpLogger{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.ClientID != "staging-id" || client.BaseURL != "https://api-staging.verda.com/v1" || client.UserAgent != "deploy-bot/1.0" {
+		t.Errorf("unexpected client settings %+v", client)
+	}
+	if client.defaultLocation() != "ICE-01" {
+		t.Errorf("expected default location ICE-01, got %s", client.defaultLocation())
+	}
+	if client.RetryPolicy == nil || client.RetryPolicy.MaxRetries != 5 {
+		t.Errorf("unexpected retry policy %+v", client.RetryPolicy)
+	}
+	// An explicit HTTP client wins over the profile timeout and is left alone
+	if client.HTTPClient != shared || shared.Timeout != 0 {
+		t.Errorf("expected the explicit HTTP client to be used unchanged")
+	}
+}
@@ -17,6 +17,7 @@ package verda
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 )
 
@@ -57,6 +58,23 @@ func (s *ContainerDeploymentsService) GetDeploymentsForProject(ctx context.Conte
 	return deployments, nil
 }
 
+// List returns the deployments matching opts; nil opts lists all of them
+func (s *ContainerDeploymentsService) List(ctx context.Context, opts *ContainerDeploymentListOptions) ([]ContainerDeployment, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// All iterates over the deployments matching opts, which are fetched
+// together in one unpaginated request
+func (s *ContainerDeploymentsService) All(ctx context.Context, opts *ContainerDeploymentListOptions) iter.Seq2[ContainerDeployment, error] {
+	o := ContainerDeploymentListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return listSeq(ctx, func(ctx context.Context) ([]ContainerDeployment, error) {
+		return s.GetDeploymentsForProject(ctx, o.ProjectID)
+	}, o.matches)
+}
+
 func (s *ContainerDeploymentsService) CreateDeployment(ctx context.Context, req *CreateDeploymentRequest) (*ContainerDeployment, error) {
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
@@ -365,3 +365,20 @@ func ValidateCreateDeploymentRequest(req *CreateDeploymentRequest) error {
 	}
 	return nil
 }
+
+// ContainerDeploymentListOptions filters ContainerDeploymentsService.List and
+// All. Zero fields do not filter.
+type ContainerDeploymentListOptions struct {
+	// ProjectID lists the deployments of a project other than the default one
+	ProjectID     string
+	Spot          SpotFilter
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	NamePrefix    string
+}
+
+func (o *ContainerDeploymentListOptions) matches(d *ContainerDeployment) bool {
+	return o.Spot.matches(d.IsSpot) &&
+		createdBetween(d.CreatedAt, o.CreatedAfter, o.CreatedBefore) &&
+		matchesPrefix(d.Name, o.NamePrefix)
+}
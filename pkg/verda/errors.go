@@ -14,13 +14,47 @@
 
 package verda
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
+// Sentinel errors matched through errors.Is. They match an *APIError by HTTP
+// status or error code; ErrNoCapacity also matches a *TerminalStatusError for
+// an instance or cluster that reached StatusNoCapacity.
+var (
+	ErrNotFound            = errors.New("verda: resource not found")
+	ErrRateLimited         = errors.New("verda: rate limited")
+	ErrConflict            = errors.New("verda: conflict")
+	ErrInsufficientBalance = errors.New("verda: insufficient balance")
+	ErrNoCapacity          = errors.New("verda: no capacity")
+)
+
+// Error codes returned in the "code" field of API error bodies
+const (
+	// ErrorCodeInsufficientFunds accompanies 402 responses
+	ErrorCodeInsufficientFunds = "insufficient_funds"
+	// ErrorCodeNoCapacity is returned by create calls when the location has
+	// no capacity for the instance type, like the StatusNoCapacity status
+	ErrorCodeNoCapacity = StatusNoCapacity
+)
+
+// APIError is returned for every non-2xx API response. The JSON fields come
+// from the error body; the rest describe the request that failed.
 type APIError struct {
 	StatusCode int    `json:"status_code,omitempty"`
 	Code       string `json:"code,omitempty"`
 	Message    string `json:"message"`
 	Details    string `json:"details,omitempty"`
+
+	Method    string      `json:"-"`
+	Path      string      `json:"-"`
+	RequestID string      `json:"-"`
+	Header    http.Header `json:"-"`
+	Body      []byte      `json:"-"`
 }
 
 func (e *APIError) Error() string {
@@ -30,6 +64,76 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
 }
 
+// Is reports whether e matches one of the sentinel errors by its HTTP status
+// or error code, so that errors.Is(err, ErrNotFound) works on wrapped API
+// errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrInsufficientBalance:
+		return e.StatusCode == http.StatusPaymentRequired || e.Code == ErrorCodeInsufficientFunds
+	case ErrNoCapacity:
+		return e.Code == ErrorCodeNoCapacity
+	default:
+		return false
+	}
+}
+
+// IsNotFound reports whether err is an API error for a missing resource
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsRateLimited reports whether err is an API error for a throttled request
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsConflict reports whether err is an API error for a conflicting change
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsInsufficientBalance reports whether err is an API error caused by the
+// account balance being too low for the request
+func IsInsufficientBalance(err error) bool {
+	return errors.Is(err, ErrInsufficientBalance)
+}
+
+// IsNoCapacity reports whether err says an instance or cluster could not be
+// placed because the location had no capacity: an API error from Create, or a
+// waiter error for a resource that reached StatusNoCapacity
+func IsNoCapacity(err error) bool {
+	return errors.Is(err, ErrNoCapacity)
+}
+
+// newAPIError builds the *APIError for a non-2xx response. JSON bodies are
+// decoded; anything else becomes the message.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	var apiError APIError
+	if err := json.Unmarshal(body, &apiError); err != nil || apiError.Message == "" && apiError.Code == "" {
+		apiError = APIError{Message: strings.TrimSpace(string(body))}
+	}
+	if apiError.Message == "" {
+		apiError.Message = http.StatusText(resp.StatusCode)
+	}
+
+	apiError.StatusCode = resp.StatusCode
+	apiError.Header = resp.Header.Clone()
+	apiError.Body = body
+	apiError.RequestID = resp.Header.Get(RequestIDHeader)
+	if resp.Request != nil {
+		apiError.Method = resp.Request.Method
+		apiError.Path = resp.Request.URL.Path
+	}
+	return &apiError
+}
+
 type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
@@ -15,7 +15,13 @@
 package verda
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
 )
 
 func TestAPIError_Error(t *testing.T) {
@@ -58,3 +64,175 @@ func TestValidationError_Error(t *testing.T) {
 		}
 	})
 }
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *APIError
+		target error
+	}{
+		{"404 is not found", &APIError{StatusCode: http.StatusNotFound}, ErrNotFound},
+		{"429 is rate limited", &APIError{StatusCode: http.StatusTooManyRequests}, ErrRateLimited},
+		{"409 is conflict", &APIError{StatusCode: http.StatusConflict}, ErrConflict},
+		{"402 is insufficient balance", &APIError{StatusCode: http.StatusPaymentRequired}, ErrInsufficientBalance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("create instance: %w", tt.err)
+			if !errors.Is(wrapped, tt.target) {
+				t.Errorf("expected %v to match %v", tt.err, tt.target)
+			}
+		})
+	}
+
+	t.Run("unrelated sentinels do not match", func(t *testing.T) {
+		err := &APIError{StatusCode: http.StatusNotFound}
+		if IsConflict(err) || IsRateLimited(err) || IsInsufficientBalance(err) || IsNoCapacity(err) {
+			t.Error("expected a 404 to match only ErrNotFound")
+		}
+		if IsNotFound(errors.New("not found")) {
+			t.Error("expected plain errors not to match")
+		}
+	})
+
+	t.Run("messages are not matched", func(t *testing.T) {
+		err := &APIError{StatusCode: http.StatusBadRequest, Code: "invalid_request", Message: "Insufficient balance, not enough capacity"}
+		if IsNotFound(err) || IsInsufficientBalance(err) || IsNoCapacity(err) {
+			t.Errorf("expected a 400 to match no sentinel, got %v", err)
+		}
+	})
+
+	t.Run("create errors", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+		client := NewTestClient(mockServer)
+		ctx := context.Background()
+
+		respond := func(status int, code string) {
+			handler := func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				_, _ = fmt.Fprintf(w, `{"code":%q,"message":"request failed"}`, code)
+			}
+			mockServer.SetHandler(http.MethodPost, "/instances", handler)
+			mockServer.SetHandler(http.MethodPost, "/clusters", handler)
+		}
+		create := map[string]func() error{
+			"instance": func() error {
+				_, err := client.Instances.Create(ctx, CreateInstanceRequest{
+					InstanceType: "1V100.6V", Image: "ubuntu-24.04", Hostname: "host", Description: "test",
+				})
+				return err
+			},
+			"cluster": func() error {
+				_, err := client.Clusters.Create(ctx, CreateClusterRequest{
+					ClusterType: "8V100.48V", Image: "ubuntu-22.04-cuda-12.0", Hostname: "cluster", Description: "test",
+					SSHKeyIDs: []string{"key_123"}, SharedVolume: ClusterSharedVolumeSpec{Name: "shared", Size: 1000},
+				})
+				return err
+			},
+		}
+		for kind, call := range create {
+			respond(http.StatusServiceUnavailable, ErrorCodeNoCapacity)
+			if err := call(); !IsNoCapacity(err) || IsInsufficientBalance(err) {
+				t.Errorf("%s: expected only ErrNoCapacity to match %v", kind, err)
+			}
+			respond(http.StatusBadRequest, ErrorCodeInsufficientFunds)
+			if err := call(); !IsInsufficientBalance(err) || IsNoCapacity(err) {
+				t.Errorf("%s: expected only ErrInsufficientBalance to match %v", kind, err)
+			}
+		}
+	})
+
+	t.Run("no capacity is a terminal status", func(t *testing.T) {
+		err := fmt.Errorf("wait: %w", &TerminalStatusError{ResourceID: "inst-1", Status: StatusNoCapacity, Wanted: StatusRunning})
+		if !IsNoCapacity(err) {
+			t.Errorf("expected %v to match ErrNoCapacity", err)
+		}
+		if IsNoCapacity(&TerminalStatusError{ResourceID: "inst-1", Status: StatusError, Wanted: StatusRunning}) {
+			t.Error("expected only StatusNoCapacity to match")
+		}
+	})
+}
+
+func TestAPIError_RequestMetadata(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	notFound := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(RequestIDHeader, "req-123")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"not_found","message":"Resource not found"}`))
+	}
+	mockServer.SetHandler(http.MethodGet, "/volumes/missing", notFound)
+	mockServer.SetHandler(http.MethodPost, "/instances", notFound)
+	mockServer.SetHandler(http.MethodPost, "/ssh-keys", notFound)
+	mockServer.SetHandler(http.MethodGet, "/instance-availability/1V100.6V", notFound)
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	calls := []struct {
+		name   string
+		method string
+		path   string
+		call   func() error
+	}{
+		{"JSON endpoint", http.MethodGet, "/volumes/missing", func() error {
+			_, err := client.Volumes.GetVolume(ctx, "missing")
+			return err
+		}},
+		{"plain text create", http.MethodPost, "/instances", func() error {
+			_, err := client.Instances.Create(ctx, CreateInstanceRequest{
+				InstanceType: "1V100.6V", Image: "ubuntu-24.04", Hostname: "host", Description: "test",
+			})
+			return err
+		}},
+		{"plain text ssh key", http.MethodPost, "/ssh-keys", func() error {
+			_, err := client.SSHKeys.AddSSHKey(ctx, &CreateSSHKeyRequest{Name: "key", PublicKey: "ssh-ed25519 AAAA"})
+			return err
+		}},
+		{"availability check", http.MethodGet, "/instance-availability/1V100.6V", func() error {
+			_, err := client.Instances.CheckInstanceTypeAvailability(ctx, "1V100.6V")
+			return err
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !IsNotFound(err) {
+				t.Fatalf("expected a not found error, got %v", err)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T", err)
+			}
+			if apiErr.Method != tt.method || apiErr.Path != tt.path {
+				t.Errorf("expected %s %s, got %s %s", tt.method, tt.path, apiErr.Method, apiErr.Path)
+			}
+			if apiErr.RequestID != "req-123" || apiErr.Header.Get(RequestIDHeader) != "req-123" {
+				t.Errorf("expected request ID req-123, got %q", apiErr.RequestID)
+			}
+			if apiErr.Code != "not_found" || apiErr.Message != "Resource not found" || len(apiErr.Body) == 0 {
+				t.Errorf("unexpected error body fields: %+v", apiErr)
+			}
+		})
+	}
+
+	t.Run("plain text bodies become the message", func(t *testing.T) {
+		mockServer.SetHandler(http.MethodGet, "/volumes/plain", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("volume is attached\n"))
+		})
+
+		_, err := client.Volumes.GetVolume(ctx, "plain")
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || !IsConflict(err) || apiErr.Message != "volume is attached" {
+			t.Errorf("expected a conflict with the body as message, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Exchange is one API call as seen by ExchangeMiddleware. Before calling
+// next, middleware may change Request and RequestBytes; what they hold then is
+// what gets sent. After next returns, Response, ResponseBytes and Result
+// describe the outcome.
+type Exchange struct {
+	Client *Client
+
+	// Request is the outgoing request. Its body is RequestBytes.
+	Request *http.Request
+	// RequestBody is the typed value the service method encoded, or nil for
+	// requests built by hand and passed to Client.Do.
+	RequestBody any
+	// RequestBytes is the encoded request body
+	RequestBytes []byte
+
+	// Response is the final response, with its body already read into
+	// ResponseBytes. Its Request field is the request as sent, including the
+	// headers added by request middleware.
+	Response      *http.Response
+	ResponseBytes []byte
+	// Result is the value passed to Client.Do, decoded from ResponseBytes on
+	// success
+	Result any
+}
+
+// Context returns the context of the exchange's request
+func (ex *Exchange) Context() context.Context {
+	return ex.Request.Context()
+}
+
+// ExchangeHandler performs an exchange, filling in its response
+type ExchangeHandler func(ex *Exchange) error
+
+// ExchangeMiddleware wraps a whole API call, like an http.RoundTripper that
+// sees the SDK's typed bodies. It may call next more than once, e.g. to retry
+// on a particular response, or not at all to answer the call itself.
+//
+// Exchange middleware runs inside Do, around request middleware, sending
+// and response middleware; the first one registered is the outermost.
+type ExchangeMiddleware func(next ExchangeHandler) ExchangeHandler
+
+// AdaptRequestMiddleware runs a RequestMiddleware as ExchangeMiddleware. The
+// middleware sees RequestBody as RequestContext.Body, and its header changes
+// are applied to the exchange's request.
+func AdaptRequestMiddleware(middleware RequestMiddleware) ExchangeMiddleware {
+	return func(next ExchangeHandler) ExchangeHandler {
+		return func(ex *Exchange) error {
+			reqCtx := ex.requestContext()
+			if err := middleware(noopRequestHandler)(reqCtx); err != nil {
+				return fmt.Errorf("request middleware failed: %w", err)
+			}
+			applyHeaders(ex.Request, reqCtx.Headers)
+			return next(ex)
+		}
+	}
+}
+
+// AdaptResponseMiddleware runs a ResponseMiddleware as ExchangeMiddleware.
+// The error of the inner exchange is passed in as ResponseContext.Error, and
+// whatever the middleware leaves there is returned.
+func AdaptResponseMiddleware(middleware ResponseMiddleware) ExchangeMiddleware {
+	return func(next ExchangeHandler) ExchangeHandler {
+		return func(ex *Exchange) error {
+			err := next(ex)
+			if ex.Response == nil {
+				return err
+			}
+
+			respCtx := &ResponseContext{
+				Request:    ex.requestContext(),
+				Response:   ex.Response,
+				Body:       ex.ResponseBytes,
+				StatusCode: ex.Response.StatusCode,
+				Error:      err,
+			}
+			if middlewareErr := middleware(noopResponseHandler)(respCtx); middlewareErr != nil {
+				return fmt.Errorf("response middleware failed: %w", middlewareErr)
+			}
+			return respCtx.Error
+		}
+	}
+}
+
+//nolint:revive // ctx unused in the no-op terminal handler
+func noopRequestHandler(ctx *RequestContext) error { return nil }
+
+//nolint:revive // ctx unused in the no-op terminal handler
+func noopResponseHandler(ctx *ResponseContext) error { return nil }
+
+func (ex *Exchange) requestContext() *RequestContext {
+	return &RequestContext{
+		Method:  ex.Request.Method,
+		Path:    ex.Request.URL.Path,
+		Body:    ex.RequestBody,
+		Headers: ex.Request.Header.Clone(),
+		Query:   ex.Request.URL.Query(),
+		Client:  ex.Client,
+		Request: ex.Request,
+	}
+}
+
+// outgoingRequest returns a copy of the exchange's request carrying
+// RequestBytes, so the exchange can be sent more than once
+func (ex *Exchange) outgoingRequest() *http.Request {
+	req := ex.Request.Clone(ex.Context())
+	if ex.RequestBytes == nil {
+		req.Body = nil
+		req.GetBody = nil
+		req.ContentLength = 0
+		return req
+	}
+
+	payload := ex.RequestBytes
+	req.Body = io.NopCloser(bytes.NewReader(payload))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(payload)), nil
+	}
+	req.ContentLength = int64(len(payload))
+	return req
+}
+
+// newExchange reads req's body so that middleware can inspect and replay it
+func (c *Client) newExchange(req *http.Request, result any) (*Exchange, error) {
+	ex := &Exchange{
+		Client:      c,
+		Request:     req,
+		RequestBody: requestBodyFrom(req.Context()),
+		Result:      result,
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		payload, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		ex.RequestBytes = payload
+	}
+	return ex, nil
+}
+
+func (c *Client) buildExchangeChain(exchangeMiddleware []ExchangeMiddleware) ExchangeHandler {
+	handler := c.exchange
+
+	// Reverse order so the first middleware is the outermost
+	for i := len(exchangeMiddleware) - 1; i >= 0; i-- {
+		handler = exchangeMiddleware[i](handler)
+	}
+
+	return handler
+}
+
+// applyHeaders replaces req's headers with those named in headers
+func applyHeaders(req *http.Request, headers http.Header) {
+	for name, values := range headers {
+		req.Header.Del(name)
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+}
+
+type requestBodyKey struct{}
+
+// withRequestBody records the typed body of a request for middleware
+func withRequestBody(ctx context.Context, body any) context.Context {
+	return context.WithValue(ctx, requestBodyKey{}, body)
+}
+
+func requestBodyFrom(ctx context.Context) any {
+	return ctx.Value(requestBodyKey{})
+}
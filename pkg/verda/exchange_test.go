@@ -0,0 +1,198 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func TestExchangeMiddleware(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	t.Run("sees typed body, raw bytes and decoded result", func(t *testing.T) {
+		client := NewTestClient(mockServer)
+
+		var body any
+		var raw []byte
+		var result any
+		var sentAuth string
+		client.AddExchangeMiddleware(func(next ExchangeHandler) ExchangeHandler {
+			return func(ex *Exchange) error {
+				if ex.Request.Method == http.MethodPost {
+					body, raw = ex.RequestBody, ex.RequestBytes
+				}
+				err := next(ex)
+				result = ex.Result
+				sentAuth = ex.Response.Request.Header.Get("Authorization")
+				return err
+			}
+		})
+
+		req := &CreateStartupScriptRequest{Name: "init", Script: "#!/bin/bash\necho hi"}
+		if _, err := client.StartupScripts.AddStartupScript(context.Background(), req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if body != req {
+			t.Errorf("expected the typed request body, got %#v", body)
+		}
+		if !bytes.Contains(raw, []byte(`"name":"init"`)) {
+			t.Errorf("expected the encoded body, got %s", raw)
+		}
+		// The last exchange is the refetch of the created script
+		if scripts, ok := result.(*[]StartupScript); !ok || len(*scripts) != 1 {
+			t.Errorf("expected the decoded scripts, got %#v", result)
+		}
+		if sentAuth == "" {
+			t.Error("expected the sent request to carry the auth header")
+		}
+	})
+
+	t.Run("request middleware sees the typed body", func(t *testing.T) {
+		client := NewTestClient(mockServer)
+
+		var body any
+		client.AddRequestMiddleware(func(next RequestHandler) RequestHandler {
+			return func(ctx *RequestContext) error {
+				if ctx.Method == http.MethodPost {
+					body = ctx.Body
+				}
+				return next(ctx)
+			}
+		})
+
+		req := &CreateSSHKeyRequest{Name: "key", PublicKey: "ssh-ed25519 AAAA"}
+		if _, err := client.SSHKeys.AddSSHKey(context.Background(), req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if body != req {
+			t.Errorf("expected the typed request body, got %#v", body)
+		}
+	})
+
+	t.Run("can rewrite and sign the body", func(t *testing.T) {
+		var gotBody, gotSignature string
+		mockServer.SetHandler(http.MethodPost, "/volumes", func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			gotBody, gotSignature = string(b), r.Header.Get("X-Signature")
+			_, _ = w.Write([]byte("vol-1"))
+		})
+
+		client := NewTestClient(mockServer)
+		client.AddExchangeMiddleware(func(next ExchangeHandler) ExchangeHandler {
+			return func(ex *Exchange) error {
+				if req, ok := ex.RequestBody.(VolumeCreateRequest); ok && req.Name == "secret" {
+					ex.RequestBytes = bytes.ReplaceAll(ex.RequestBytes, []byte("secret"), []byte("redacted"))
+				}
+				sum := sha256.Sum256(ex.RequestBytes)
+				ex.Request.Header.Set("X-Signature", hex.EncodeToString(sum[:]))
+				return next(ex)
+			}
+		})
+
+		if _, err := client.Volumes.CreateVolume(context.Background(), VolumeCreateRequest{Type: VolumeTypeNVMe, Name: "secret", Size: 10}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sum := sha256.Sum256([]byte(gotBody))
+		if !bytes.Contains([]byte(gotBody), []byte(`"name":"redacted"`)) || gotSignature != hex.EncodeToString(sum[:]) {
+			t.Errorf("expected a signed, rewritten body, got %s (%s)", gotBody, gotSignature)
+		}
+	})
+
+	t.Run("can replay on a specific response", func(t *testing.T) {
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/balance", func(w http.ResponseWriter, _ *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"code":"conflict","message":"busy"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"amount":5,"currency":"eur"}`))
+		})
+
+		client := NewTestClient(mockServer)
+		client.AddExchangeMiddleware(func(next ExchangeHandler) ExchangeHandler {
+			return func(ex *Exchange) error {
+				if err := next(ex); !IsConflict(err) {
+					return err
+				}
+				return next(ex)
+			}
+		})
+
+		balance, err := client.Balance.Get(context.Background())
+		if err != nil || balance.Amount != 5 {
+			t.Fatalf("expected the replayed balance, got %+v, %v", balance, err)
+		}
+		if atomic.LoadInt32(&calls) != 2 {
+			t.Errorf("expected 2 calls, got %d", calls)
+		}
+	})
+}
+
+func TestAdaptMiddleware(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var gotHeader string
+	mockServer.SetHandler(http.MethodGet, "/balance", func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Tenant")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client := NewTestClient(mockServer)
+	client.SetExchangeMiddleware([]ExchangeMiddleware{
+		AdaptResponseMiddleware(func(next ResponseHandler) ResponseHandler {
+			return func(ctx *ResponseContext) error {
+				if IsNotFound(ctx.Error) {
+					ctx.Error = nil
+				}
+				return next(ctx)
+			}
+		}),
+		AdaptRequestMiddleware(func(next RequestHandler) RequestHandler {
+			return func(ctx *RequestContext) error {
+				ctx.Headers.Set("X-Tenant", "acme")
+				return next(ctx)
+			}
+		}),
+	})
+	if client.Middleware.LenExchangeMiddleware() != 2 {
+		t.Fatalf("expected 2 exchange middleware, got %d", client.Middleware.LenExchangeMiddleware())
+	}
+
+	if _, err := client.Balance.Get(context.Background()); err != nil {
+		t.Errorf("expected the response middleware to clear the error, got %v", err)
+	}
+	if gotHeader != "acme" {
+		t.Errorf("expected the request middleware's header, got %q", gotHeader)
+	}
+
+	client.ClearExchangeMiddleware()
+	if _, err := client.Balance.Get(context.Background()); !IsNotFound(err) {
+		t.Errorf("expected a not found error without middleware, got %v", err)
+	}
+}
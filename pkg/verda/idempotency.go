@@ -0,0 +1,90 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a mutating request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that makes mutating requests use key as
+// their idempotency key instead of a generated one. Reuse the same key when
+// retrying a create call after a timeout so the API can return the original
+// result rather than provisioning a second resource.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the key set with WithIdempotencyKey, if any
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// NewIdempotencyKey returns a random UUIDv4 suitable for use as an idempotency key
+func NewIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms
+		panic(fmt.Sprintf("verda: failed to generate idempotency key: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// isMutatingMethod reports whether requests with method change server state
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// idempotencyKeyFor picks the idempotency key for a request: an explicit header
+// wins, then a key from the context, then a freshly generated one. Non-mutating
+// requests get no key.
+func idempotencyKeyFor(ctx context.Context, method string, headers http.Header) string {
+	if !isMutatingMethod(method) {
+		return ""
+	}
+	if key := headers.Get(IdempotencyKeyHeader); key != "" {
+		return key
+	}
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		return key
+	}
+	return NewIdempotencyKey()
+}
+
+// withIdempotencyKeySuffix gives one of the several requests a call sends its
+// own key, derived from the key set with WithIdempotencyKey, so that they do
+// not replay each other's responses while a retried call reuses the same keys.
+// Without a key in ctx each request is keyed as usual.
+func withIdempotencyKeySuffix(ctx context.Context, suffix string) context.Context {
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		return WithIdempotencyKey(ctx, key+"-"+suffix)
+	}
+	return ctx
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+var uuidV4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// keyRecorder captures the Idempotency-Key header of every request it sees
+type keyRecorder struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (k *keyRecorder) record(r *http.Request) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = append(k.keys, r.Header.Get(IdempotencyKeyHeader))
+}
+
+func (k *keyRecorder) snapshot() []string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return append([]string{}, k.keys...)
+}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		key := NewIdempotencyKey()
+		if !uuidV4Pattern.MatchString(key) {
+			t.Fatalf("expected a UUIDv4, got %q", key)
+		}
+		if seen[key] {
+			t.Fatalf("duplicate key %q", key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("mutating requests get a key, reads do not", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var post, get keyRecorder
+		mockServer.SetHandler(http.MethodPost, "/things", func(w http.ResponseWriter, r *http.Request) {
+			post.record(r)
+			w.WriteHeader(http.StatusCreated)
+		})
+		mockServer.SetHandler(http.MethodGet, "/things", func(w http.ResponseWriter, r *http.Request) {
+			get.record(r)
+			_, _ = w.Write([]byte(`[]`))
+		})
+
+		client := NewTestClient(mockServer)
+		if _, err := postRequestAllowEmptyResponse(ctx, client, "/things", map[string]string{"a": "b"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, _, err := getRequest[[]string](ctx, client, "/things"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if keys := post.snapshot(); len(keys) != 1 || !uuidV4Pattern.MatchString(keys[0]) {
+			t.Errorf("expected a generated key on POST, got %v", keys)
+		}
+		if keys := get.snapshot(); len(keys) != 1 || keys[0] != "" {
+			t.Errorf("expected no key on GET, got %v", keys)
+		}
+	})
+
+	t.Run("each call gets a fresh key", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var rec keyRecorder
+		mockServer.SetHandler(http.MethodDelete, "/things/1", func(w http.ResponseWriter, r *http.Request) {
+			rec.record(r)
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		client := NewTestClient(mockServer)
+		for i := 0; i < 2; i++ {
+			if _, err := deleteRequestAllowEmptyResponse(ctx, client, "/things/1"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if keys := rec.snapshot(); len(keys) != 2 || keys[0] == keys[1] {
+			t.Errorf("expected two distinct keys, got %v", keys)
+		}
+	})
+
+	t.Run("retries reuse the same key", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var rec keyRecorder
+		mockServer.SetHandler(http.MethodPost, "/things", func(w http.ResponseWriter, r *http.Request) {
+			rec.record(r)
+			if len(rec.snapshot()) < 3 {
+				testutil.ErrorResponse(w, http.StatusServiceUnavailable, "try again")
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		client := newRetryTestClient(mockServer, &RetryPolicy{
+			MaxRetries:         3,
+			InitialDelay:       time.Millisecond,
+			RetryNonIdempotent: true,
+		})
+		if _, err := postRequestAllowEmptyResponse(ctx, client, "/things", map[string]string{"a": "b"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		keys := rec.snapshot()
+		if len(keys) != 3 {
+			t.Fatalf("expected 3 attempts, got %d", len(keys))
+		}
+		if keys[0] == "" || keys[0] != keys[1] || keys[1] != keys[2] {
+			t.Errorf("expected the same key on every attempt, got %v", keys)
+		}
+	})
+
+	t.Run("explicit header is kept", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var rec keyRecorder
+		mockServer.SetHandler(http.MethodPost, "/things", func(w http.ResponseWriter, r *http.Request) {
+			rec.record(r)
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		client := NewTestClient(mockServer)
+		req, err := client.NewRequest(WithIdempotencyKey(ctx, "from-context"), http.MethodPost, "/things", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		req.Header.Set(IdempotencyKeyHeader, "from-header")
+		if _, err := client.Do(req, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if keys := rec.snapshot(); len(keys) != 1 || keys[0] != "from-header" {
+			t.Errorf("expected the explicit header to win, got %v", keys)
+		}
+	})
+}
+
+func TestIdempotencyKeys_CreateDeduplication(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("instances", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		client := NewTestClient(mockServer)
+		keyCtx := WithIdempotencyKey(ctx, "create-instance-1")
+		req := CreateInstanceRequest{
+			InstanceType: "1V100.6V",
+			Image:        "ubuntu-24.04-cuda-12.8-open-docker",
+			Hostname:     "dedup-instance",
+			Description:  "dedup",
+		}
+
+		first, err := client.Instances.Create(keyCtx, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		second, err := client.Instances.Create(keyCtx, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if first.ID != second.ID {
+			t.Errorf("expected the replayed instance, got %s and %s", first.ID, second.ID)
+		}
+		if n := mockServer.IdempotencyKeyRequests("create-instance-1"); n != 2 {
+			t.Errorf("expected 2 requests with the key, got %d", n)
+		}
+	})
+
+	t.Run("volumes reach the handler once", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var rec keyRecorder
+		mockServer.SetHandler(http.MethodPost, "/volumes", func(w http.ResponseWriter, r *http.Request) {
+			rec.record(r)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte("vol_dedup"))
+		})
+
+		client := NewTestClient(mockServer)
+		keyCtx := WithIdempotencyKey(ctx, "create-volume-1")
+		req := VolumeCreateRequest{Name: "dedup", Size: 10, Type: VolumeTypeNVMe}
+
+		for i := 0; i < 2; i++ {
+			id, err := client.Volumes.CreateVolume(keyCtx, req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != "vol_dedup" {
+				t.Errorf("expected vol_dedup, got %s", id)
+			}
+		}
+
+		if keys := rec.snapshot(); len(keys) != 1 {
+			t.Errorf("expected the handler to run once, ran %d times", len(keys))
+		}
+	})
+
+	t.Run("clusters", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var rec keyRecorder
+		mockServer.SetHandler(http.MethodPost, "/clusters", func(w http.ResponseWriter, r *http.Request) {
+			rec.record(r)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"cluster_dedup"}`))
+		})
+
+		client := NewTestClient(mockServer)
+		keyCtx := WithIdempotencyKey(ctx, "create-cluster-1")
+		req := CreateClusterRequest{
+			ClusterType:  "16H200",
+			Image:        "ubuntu-22.04-cuda-12.4-cluster",
+			Hostname:     "dedup-cluster",
+			Description:  "dedup",
+			SharedVolume: ClusterSharedVolumeSpec{Name: "shared", Size: 100},
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.Clusters.Create(keyCtx, req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if keys := rec.snapshot(); len(keys) != 1 {
+			t.Errorf("expected the handler to run once, ran %d times", len(keys))
+		}
+	})
+}
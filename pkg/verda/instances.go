@@ -16,18 +16,44 @@ package verda
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"iter"
 	"net/url"
-	"strings"
 )
 
 type InstanceService struct {
 	client *Client
 }
 
+// List returns the instances matching opts; nil opts lists all of them
+func (s *InstanceService) List(ctx context.Context, opts *InstanceListOptions) ([]Instance, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// All iterates over the instances matching opts. The endpoint is not
+// paginated, so all instances are fetched in one request before the first is
+// yielded:
+//
+//	for instance, err := range client.Instances.All(ctx, &verda.InstanceListOptions{Spot: verda.SpotOnly}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(instance.Hostname)
+//	}
+func (s *InstanceService) All(ctx context.Context, opts *InstanceListOptions) iter.Seq2[Instance, error] {
+	o := InstanceListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	status := ""
+	if len(o.Statuses) == 1 {
+		status = o.Statuses[0]
+	}
+	return listSeq(ctx, func(ctx context.Context) ([]Instance, error) {
+		return s.Get(ctx, status)
+	}, o.matches)
+}
+
 func (s *InstanceService) Get(ctx context.Context, status string) ([]Instance, error) {
 	path := "/instances"
 	if status != "" {
@@ -60,7 +86,7 @@ func (s *InstanceService) Create(ctx context.Context, req CreateInstanceRequest)
 	}
 
 	if req.LocationCode == "" {
-		req.LocationCode = LocationFIN03
+		req.LocationCode = s.client.defaultLocation()
 	}
 
 	if req.SSHKeyIDs == nil {
@@ -72,85 +98,77 @@ func (s *InstanceService) Create(ctx context.Context, req CreateInstanceRequest)
 
 // createWithPlainTextResponse handles API's inconsistent response format (sometimes JSON, sometimes plain text ID)
 func (s *InstanceService) createWithPlainTextResponse(ctx context.Context, req CreateInstanceRequest) (*Instance, error) {
-	resp, err := s.client.makeRequest(ctx, http.MethodPost, "/instances", req)
+	created, _, err := postRequest[createdResource[Instance]](ctx, s.client, "/instances", req)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read error response: %w", err)
-		}
-		s.client.Logger.Debug("Instance creation failed with status %d: %s", resp.StatusCode, string(body))
-
-		var apiError APIError
-		if err := json.Unmarshal(body, &apiError); err != nil {
-			return nil, &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    string(body),
-			}
-		}
-		apiError.StatusCode = resp.StatusCode
-		return nil, &apiError
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	var instance Instance
-	if err := json.Unmarshal(body, &instance); err != nil {
-		// Fall back to plain text ID
-		instanceID := strings.TrimSpace(string(body))
-		return s.GetByID(ctx, instanceID)
+	if created.Resource != nil {
+		return created.Resource, nil
 	}
-
-	return &instance, nil
+	return s.GetByID(ctx, created.ID)
 }
 
-// Action performs an action on one or more instances.
+// Action performs an action on one or more instances in a single request.
+// Instances the action failed for are only reported in their results; see
+// BatchAction.
 func (s *InstanceService) Action(ctx context.Context, req InstanceActionRequest) ([]InstanceActionResult, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.makeRequest(ctx, http.MethodPut, "/instances", req)
+	results, _, err := putRequest[[]InstanceActionResult](ctx, s.client, "/instances", req)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	return results, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+// BatchAction performs an action on any number of instances, ActionBatchSize
+// at a time, and returns the result for each. If the action failed for some
+// instances the error is a *BatchActionError listing them. A request that
+// fails as a whole stops the batch with its error, wrapped in a
+// *BatchStoppedError once earlier requests went through. Requests with VolumeIDs are
+// sent whole, as the volumes cannot be matched to their instances. With a key
+// from WithIdempotencyKey, each request uses the key followed by "-" and its
+// chunk index when there are several.
+//
+//	results, err := client.Instances.BatchAction(ctx, verda.InstanceActionRequest{Action: verda.ActionShutdown, ID: ids})
+//	var batchErr *verda.BatchActionError
+//	if errors.As(err, &batchErr) {
+//		retry(batchErr.FailedIDs())
+//	}
+func (s *InstanceService) BatchAction(ctx context.Context, req InstanceActionRequest) ([]InstanceActionResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
-	switch resp.StatusCode {
-	case http.StatusNoContent:
-		return nil, nil
-
-	case http.StatusAccepted, http.StatusMultiStatus:
-		var results []InstanceActionResult
-		if err := json.Unmarshal(body, &results); err != nil {
-			return nil, fmt.Errorf("failed to parse action results: %w", err)
+	chunks := [][]string{req.ID}
+	if len(req.VolumeIDs) == 0 {
+		chunks = chunkIDs(req.ID)
+	}
+	var results []InstanceActionResult
+	done := 0
+	for i, ids := range chunks {
+		chunk := req
+		chunk.ID = ids
+		chunkResults, err := s.Action(chunkContext(ctx, i, len(chunks)), chunk)
+		if err != nil {
+			return results, batchStopped(ResourceKindInstance, req.Action, done, len(req.ID), err)
 		}
-		return results, nil
+		results = append(results, chunkResults...)
+		done += len(ids)
+	}
 
-	default:
-		var apiError APIError
-		if err := json.Unmarshal(body, &apiError); err != nil {
-			return nil, &APIError{StatusCode: resp.StatusCode, Message: string(body)}
+	batchErr := &BatchActionError{Kind: ResourceKindInstance, Action: req.Action, Total: len(req.ID)}
+	for _, result := range results {
+		if result.Failed() {
+			batchErr.Failures = append(batchErr.Failures, BatchFailure{ID: result.InstanceID, Message: result.message(), StatusCode: result.StatusCode})
 		}
-		apiError.StatusCode = resp.StatusCode
-		return nil, &apiError
 	}
+	if len(batchErr.Failures) > 0 {
+		return results, batchErr
+	}
+	return results, nil
 }
 
 func (s *InstanceService) GetLocationAvailabilities(ctx context.Context) ([]LocationAvailability, error) {
@@ -166,101 +184,85 @@ func (s *InstanceService) CheckInstanceTypeAvailability(ctx context.Context, ins
 	path := fmt.Sprintf("/instance-availability/%s", instanceType)
 
 	// API returns "true"/"false" as JSON string, not boolean
-	resp, err := s.client.makeRequest(ctx, http.MethodGet, path, nil)
+	available, _, err := getRequest[bool](ctx, s.client, path)
 	if err != nil {
 		return false, err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return false, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var apiError APIError
-		if err := json.Unmarshal(body, &apiError); err != nil {
-			return false, &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    string(body),
-			}
-		}
-		apiError.StatusCode = resp.StatusCode
-		return false, &apiError
-	}
-
-	var boolResult bool
-	if err := json.Unmarshal(body, &boolResult); err == nil {
-		return boolResult, nil
-	}
-
-	var stringResult string
-	if err := json.Unmarshal(body, &stringResult); err == nil {
-		return stringResult == trueString, nil
-	}
-
-	return false, fmt.Errorf("unexpected response format: %s", string(body))
+	return available, nil
 }
 
+// Boot boots instances. Like the other action methods, it sends long ID lists
+// in several requests and returns a *BatchActionError if the action failed
+// for some instances, or a *BatchStoppedError saying how many instances were
+// sent before a later request failed; BatchAction also returns the
+// per-instance results.
 func (s *InstanceService) Boot(ctx context.Context, ids ...string) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionBoot, ID: ids})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionBoot, ID: ids})
 	return err
 }
 
 func (s *InstanceService) Start(ctx context.Context, ids ...string) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionStart, ID: ids})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionStart, ID: ids})
 	return err
 }
 
 func (s *InstanceService) Shutdown(ctx context.Context, ids ...string) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionShutdown, ID: ids})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionShutdown, ID: ids})
 	return err
 }
 
 func (s *InstanceService) Delete(ctx context.Context, ids []string, volumeIDs []string, deletePermanently bool) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionDelete, ID: ids, VolumeIDs: volumeIDs, DeletePermanently: deletePermanently})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionDelete, ID: ids, VolumeIDs: volumeIDs, DeletePermanently: deletePermanently})
 	return err
 }
 
 func (s *InstanceService) Discontinue(ctx context.Context, ids []string, volumeIDs []string, deletePermanently bool) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionDiscontinue, ID: ids, VolumeIDs: volumeIDs, DeletePermanently: deletePermanently})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionDiscontinue, ID: ids, VolumeIDs: volumeIDs, DeletePermanently: deletePermanently})
 	return err
 }
 
 // Hibernate shuts down and archives an instance - must be shut down first or API will error.
 // Volumes are detached and the instance is deleted during hibernation.
 func (s *InstanceService) Hibernate(ctx context.Context, ids ...string) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionHibernate, ID: ids})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionHibernate, ID: ids})
 	return err
 }
 
 func (s *InstanceService) ConfigureSpot(ctx context.Context, ids ...string) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionConfigureSpot, ID: ids})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionConfigureSpot, ID: ids})
 	return err
 }
 
 func (s *InstanceService) ForceShutdown(ctx context.Context, ids ...string) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionForceShutdown, ID: ids})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionForceShutdown, ID: ids})
 	return err
 }
 
 func (s *InstanceService) DeleteStuck(ctx context.Context, volumeIDs []string, ids ...string) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionDeleteStuck, ID: ids, VolumeIDs: volumeIDs})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionDeleteStuck, ID: ids, VolumeIDs: volumeIDs})
 	return err
 }
 
 func (s *InstanceService) Deploy(ctx context.Context, ids ...string) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionDeploy, ID: ids})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionDeploy, ID: ids})
 	return err
 }
 
 func (s *InstanceService) Transfer(ctx context.Context, ids ...string) error {
-	_, err := s.Action(ctx, InstanceActionRequest{Action: ActionTransfer, ID: ids})
+	_, err := s.BatchAction(ctx, InstanceActionRequest{Action: ActionTransfer, ID: ids})
 	return err
 }
 
+// WaitForStatus polls the instance until it reaches status. It fails fast with a
+// *TerminalStatusError if the instance ends up in StatusError, StatusNoCapacity
+// or StatusDiscontinued instead.
+func (s *InstanceService) WaitForStatus(ctx context.Context, id, status string, opts *WaitOptions) (*Instance, error) {
+	return waitForResource(ctx, id, status, instanceTerminalStatuses, opts, s.GetByID,
+		func(instance *Instance) (string, bool) {
+			return instance.Status, instance.Status == status
+		})
+}
+
 // AddTag adds a single key-value tag to an instance. Maximum 10 tags per instance.
 // Leave req.Value empty for a freeform tag.
 //
@@ -274,3 +276,22 @@ func (s *InstanceService) AddTag(ctx context.Context, instanceID string, req Tag
 func (s *InstanceService) DeleteTag(ctx context.Context, instanceID, key string) error {
 	return deleteResourceTag(ctx, s.client, "/instances", instanceID, key)
 }
+
+// SetTags makes an instance carry exactly the desired tags, a map of keys to
+// values where an empty value is a freeform tag. desired is checked against
+// MaxTagsPerResource, TagKeyMaxLength and TagValueMaxLength before any
+// request is sent. Only the tags that differ are deleted or added; a changed
+// value takes a delete and an add, as the API has no update.
+//
+// On failure the returned changes are those made before the failed request.
+//
+//	changes, err := client.Instances.SetTags(ctx, instanceID, map[string]string{"team": "ml", "gpu": ""})
+func (s *InstanceService) SetTags(ctx context.Context, instanceID string, desired map[string]string) (*TagChanges, error) {
+	return setResourceTags(ctx, s.client, "/instances", instanceID, desired, func(ctx context.Context) ([]Tag, error) {
+		instance, err := s.GetByID(ctx, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		return instance.Tags, nil
+	})
+}
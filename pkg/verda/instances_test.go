@@ -18,8 +18,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
@@ -614,3 +618,163 @@ func writeTestJSON(w http.ResponseWriter, v interface{}) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestInstanceService_BatchAction(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	// The handler fails the action for IDs starting with "bad" and records
+	// the number of IDs in each request
+	var mu sync.Mutex
+	var requests [][]string
+	var keys []string
+	mockServer.SetHandler(http.MethodPut, "/instances", func(w http.ResponseWriter, r *http.Request) {
+		var req InstanceActionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, req.ID)
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		mu.Unlock()
+
+		status := http.StatusAccepted
+		var results []InstanceActionResult
+		for _, id := range req.ID {
+			result := InstanceActionResult{Action: req.Action, InstanceID: id, Status: ActionStatusSuccess}
+			if strings.HasPrefix(id, "bad") {
+				status = http.StatusMultiStatus
+				result = InstanceActionResult{Action: req.Action, InstanceID: id, Status: ActionStatusError, Error: "instance not found", StatusCode: http.StatusNotFound}
+			}
+			results = append(results, result)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		writeTestJSON(w, results)
+	})
+	reset := func() {
+		mu.Lock()
+		requests = nil
+		keys = nil
+		mu.Unlock()
+	}
+
+	t.Run("partial failure is an error", func(t *testing.T) {
+		reset()
+		err := client.Instances.Shutdown(ctx, "inst_1", "bad_2", "inst_3")
+		var batchErr *BatchActionError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("expected *BatchActionError, got %T: %v", err, err)
+		}
+		if got := batchErr.FailedIDs(); !slices.Equal(got, []string{"bad_2"}) {
+			t.Errorf("expected bad_2 to fail, got %v", got)
+		}
+		want := "shutdown failed for 1 of 3 instances: bad_2: instance not found (404)"
+		if err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+	})
+
+	t.Run("long ID lists are chunked", func(t *testing.T) {
+		reset()
+		ids := make([]string, 2*ActionBatchSize+20)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("inst_%d", i)
+		}
+		ids[len(ids)-1] = "bad_last"
+
+		results, err := client.Instances.BatchAction(ctx, InstanceActionRequest{Action: ActionStart, ID: ids})
+		var batchErr *BatchActionError
+		if !errors.As(err, &batchErr) || batchErr.Total != len(ids) {
+			t.Errorf("expected a *BatchActionError for %d instances, got %v", len(ids), err)
+		}
+		if len(results) != len(ids) {
+			t.Errorf("expected %d results, got %d", len(ids), len(results))
+		}
+		if len(requests) != 3 || len(requests[0]) != ActionBatchSize || len(requests[2]) != 20 {
+			t.Errorf("unexpected request sizes: %d requests", len(requests))
+		}
+	})
+
+	t.Run("each chunk gets its own idempotency key", func(t *testing.T) {
+		reset()
+		ids := make([]string, ActionBatchSize+1)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("inst_%d", i)
+		}
+		if err := client.Instances.Start(WithIdempotencyKey(ctx, "start-1"), ids...); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []string{"start-1-0", "start-1-1"}; !slices.Equal(keys, want) {
+			t.Errorf("expected keys %v, got %v", want, keys)
+		}
+
+		reset()
+		if err := client.Instances.Start(WithIdempotencyKey(ctx, "start-2"), "inst_1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []string{"start-2"}; !slices.Equal(keys, want) {
+			t.Errorf("expected a single request to keep the key, got %v", keys)
+		}
+	})
+
+	t.Run("requests with volumes are not chunked", func(t *testing.T) {
+		reset()
+		ids := make([]string, ActionBatchSize+1)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("inst_%d", i)
+		}
+		if err := client.Instances.Delete(ctx, ids, []string{"vol_1"}, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(requests) != 1 {
+			t.Errorf("expected a single request, got %d", len(requests))
+		}
+	})
+
+	t.Run("a failed request stops the batch", func(t *testing.T) {
+		calls := 0
+		mockServer.SetHandler(http.MethodPut, "/instances", func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 2 {
+				testutil.ErrorResponse(w, http.StatusBadRequest, "action not allowed in current state")
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusAccepted)
+			writeTestJSON(w, []InstanceActionResult{{InstanceID: "inst_0", Status: ActionStatusSuccess}})
+		})
+
+		ids := make([]string, 3*ActionBatchSize)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("inst_%d", i)
+		}
+		results, err := client.Instances.BatchAction(ctx, InstanceActionRequest{Action: ActionBoot, ID: ids})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected the 400 *APIError, got %v", err)
+		}
+		if len(results) != 1 || calls != 2 {
+			t.Errorf("expected the results of the first request only, got %d results from %d calls", len(results), calls)
+		}
+		var stopped *BatchStoppedError
+		if !errors.As(err, &stopped) || stopped.Done != ActionBatchSize || stopped.Total != len(ids) {
+			t.Fatalf("expected a *BatchStoppedError after the first request, got %v", err)
+		}
+
+		calls = 0
+		err = client.Instances.Boot(ctx, ids...)
+		want := fmt.Sprintf("boot stopped after %d of %d instances: API error 400: action not allowed in current state", ActionBatchSize, len(ids))
+		if err == nil || err.Error() != want {
+			t.Errorf("expected %q from the wrapper, got %v", want, err)
+		}
+
+		calls = 1
+		if err := client.Instances.Boot(ctx, ids...); errors.As(err, &stopped) {
+			t.Errorf("expected a failed first request to return its error as is, got %v", err)
+		}
+	})
+}
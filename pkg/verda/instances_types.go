@@ -119,6 +119,24 @@ type InstanceActionResult struct {
 	StatusCode int    `json:"statusCode,omitempty"`
 }
 
+// Statuses of an InstanceActionResult
+const (
+	ActionStatusSuccess = "success"
+	ActionStatusError   = "error"
+)
+
+// Failed reports whether the action failed for the instance
+func (r InstanceActionResult) Failed() bool {
+	return r.Status == ActionStatusError || r.Error != ""
+}
+
+func (r InstanceActionResult) message() string {
+	if r.Error != "" {
+		return r.Error
+	}
+	return r.Status
+}
+
 // Action constants
 const (
 	ActionBoot          = "boot"
@@ -193,3 +211,28 @@ func (r InstanceActionRequest) Validate() error {
 		validation.Field(&r.ID, validation.Required, validation.Length(1, 0)),
 	)
 }
+
+// InstanceListOptions filters InstanceService.List and All. Zero fields do
+// not filter.
+type InstanceListOptions struct {
+	// Statuses keeps instances in any of these statuses; a single status is
+	// filtered by the API
+	Statuses       []string
+	Location       string
+	InstanceType   string
+	Spot           SpotFilter
+	Tags           TagSelector
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	HostnamePrefix string
+}
+
+func (o *InstanceListOptions) matches(i *Instance) bool {
+	return matchesStatus(i.Status, o.Statuses) &&
+		(o.Location == "" || i.Location == o.Location) &&
+		(o.InstanceType == "" || i.InstanceType == o.InstanceType) &&
+		o.Spot.matches(i.IsSpot) &&
+		matchesTags(o.Tags, i.Tags) &&
+		createdBetween(i.CreatedAt, o.CreatedAfter, o.CreatedBefore) &&
+		matchesPrefix(i.Hostname, o.HostnamePrefix)
+}
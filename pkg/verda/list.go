@@ -0,0 +1,124 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SpotFilter selects spot or non-spot resources in list options
+type SpotFilter int
+
+const (
+	// SpotAny does not filter on spot pricing
+	SpotAny SpotFilter = iota
+	// SpotOnly keeps spot resources only
+	SpotOnly
+	// NoSpot keeps non-spot resources only
+	NoSpot
+)
+
+func (f SpotFilter) matches(isSpot bool) bool {
+	switch f {
+	case SpotOnly:
+		return isSpot
+	case NoSpot:
+		return !isSpot
+	default:
+		return true
+	}
+}
+
+// TagSelector matches resources by their tags. Build one with MatchTags or
+// ParseTagSelector.
+type TagSelector interface {
+	Matches(tags []Tag) bool
+}
+
+// MatchTags returns a selector for resources carrying every tag in tags. An
+// empty value matches any value of its key.
+func MatchTags(tags map[string]string) TagSelector {
+	return tagSet(tags)
+}
+
+type tagSet map[string]string
+
+func (s tagSet) Matches(tags []Tag) bool {
+	for key, value := range s {
+		if !slices.ContainsFunc(tags, func(tag Tag) bool {
+			return tag.Key == key && (value == "" || tag.Value == value)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// createdBetween reports whether created is in [after, before). Zero times
+// leave that side open.
+func createdBetween(created, after, before time.Time) bool {
+	return (after.IsZero() || !created.Before(after)) &&
+		(before.IsZero() || created.Before(before))
+}
+
+// matchesStatus reports whether status is one of statuses, or statuses is empty
+func matchesStatus(status string, statuses []string) bool {
+	return len(statuses) == 0 || slices.Contains(statuses, status)
+}
+
+func matchesTags(selector TagSelector, tags []Tag) bool {
+	return selector == nil || selector.Matches(tags)
+}
+
+func matchesPrefix(name, prefix string) bool {
+	return strings.HasPrefix(name, prefix)
+}
+
+// listSeq yields the items fetch returns that match. The list endpoints are
+// not paginated: fetch loads the whole collection in one request before the
+// first item is yielded, so iterating uses no less memory than List. Filters
+// the API does not support run client-side. A fetch error is yielded once,
+// with the zero item.
+func listSeq[T any](ctx context.Context, fetch func(context.Context) ([]T, error), match func(*T) bool) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		items, err := fetch(ctx)
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for i := range items {
+			if match(&items[i]) && !yield(items[i], nil) {
+				return
+			}
+		}
+	}
+}
+
+// collect gathers the items of seq, stopping at the first error
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
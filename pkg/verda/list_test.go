@@ -0,0 +1,221 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func jsonHandler(t *testing.T, v any) http.HandlerFunc {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to encode fixture: %v", err)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}
+}
+
+func hostnames(instances []Instance) []string {
+	names := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		names = append(names, instance.Hostname)
+	}
+	return names
+}
+
+func TestInstanceList(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	fixture := []Instance{
+		{ID: "1", Hostname: "train-a", Status: StatusRunning, Location: LocationFIN01, InstanceType: "1V100.6V", IsSpot: true, CreatedAt: day(1), Tags: []Tag{{Key: "team", Value: "ml"}}},
+		{ID: "2", Hostname: "train-b", Status: StatusOffline, Location: LocationFIN01, InstanceType: "8A100.176V", CreatedAt: day(5), Tags: []Tag{{Key: "team", Value: "ml"}, {Key: "env", Value: "prod"}}},
+		{ID: "3", Hostname: "web", Status: StatusRunning, Location: LocationFIN03, InstanceType: "1V100.6V", CreatedAt: day(10)},
+	}
+
+	var lastStatus atomic.Value
+	var requests atomic.Int32
+	handler := jsonHandler(t, fixture)
+	mockServer.SetHandler(http.MethodGet, "/instances", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		lastStatus.Store(r.URL.Query().Get("status"))
+		handler(w, r)
+	})
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts *InstanceListOptions
+		want []string
+	}{
+		{"no options", nil, []string{"train-a", "train-b", "web"}},
+		{"several statuses", &InstanceListOptions{Statuses: []string{StatusRunning, StatusOffline}}, []string{"train-a", "train-b", "web"}},
+		{"location", &InstanceListOptions{Location: LocationFIN03}, []string{"web"}},
+		{"instance type", &InstanceListOptions{InstanceType: "1V100.6V"}, []string{"train-a", "web"}},
+		{"spot only", &InstanceListOptions{Spot: SpotOnly}, []string{"train-a"}},
+		{"no spot", &InstanceListOptions{Spot: NoSpot}, []string{"train-b", "web"}},
+		{"tags", &InstanceListOptions{Tags: MatchTags(map[string]string{"team": "ml", "env": ""})}, []string{"train-b"}},
+		{"created range", &InstanceListOptions{CreatedAfter: day(5), CreatedBefore: day(10)}, []string{"train-b"}},
+		{"hostname prefix", &InstanceListOptions{HostnamePrefix: "train-"}, []string{"train-a", "train-b"}},
+		{"combined", &InstanceListOptions{HostnamePrefix: "train-", Spot: NoSpot, Location: LocationFIN01}, []string{"train-b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instances, err := client.Instances.List(ctx, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := hostnames(instances); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("a single status is filtered by the API", func(t *testing.T) {
+		_, _ = client.Instances.List(ctx, &InstanceListOptions{Statuses: []string{StatusRunning}})
+		if got := lastStatus.Load(); got != StatusRunning {
+			t.Errorf("expected status=%s in the query, got %q", StatusRunning, got)
+		}
+		_, _ = client.Instances.List(ctx, &InstanceListOptions{Statuses: []string{StatusRunning, StatusOffline}})
+		if got := lastStatus.Load(); got != "" {
+			t.Errorf("expected no status in the query, got %q", got)
+		}
+	})
+
+	t.Run("iterator stops early", func(t *testing.T) {
+		requests.Store(0)
+		var seen []string
+		for instance, err := range client.Instances.All(ctx, nil) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			seen = append(seen, instance.Hostname)
+			if len(seen) == 2 {
+				break
+			}
+		}
+		if len(seen) != 2 || requests.Load() != 1 {
+			t.Errorf("expected 2 items from 1 request, got %v from %d", seen, requests.Load())
+		}
+	})
+
+	t.Run("errors are yielded", func(t *testing.T) {
+		mockServer.SetHandler(http.MethodGet, "/instances", func(w http.ResponseWriter, r *http.Request) {
+			testutil.ErrorResponse(w, http.StatusInternalServerError, "boom")
+		})
+		var errs int
+		for _, err := range client.Instances.All(ctx, nil) {
+			if err != nil {
+				errs++
+			}
+		}
+		if errs != 1 {
+			t.Errorf("expected one error, got %d", errs)
+		}
+		if _, err := client.Instances.List(ctx, nil); err == nil {
+			t.Error("expected List to return the error")
+		}
+	})
+}
+
+func TestCollectionList(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	early := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockServer.SetHandler(http.MethodGet, "/volumes", jsonHandler(t, []Volume{
+		{ID: "v1", Name: "data-1", Type: VolumeTypeNVMe, Status: "attached", Location: LocationFIN01, CreatedAt: early},
+		{ID: "v2", Name: "data-2", Type: VolumeTypeHDD, Status: "detached", Location: LocationFIN01, CreatedAt: late},
+		{ID: "v3", Name: "scratch", Type: VolumeTypeNVMe, Status: "detached", Location: LocationFIN03, CreatedAt: late},
+	}))
+	mockServer.SetHandler(http.MethodGet, "/clusters", jsonHandler(t, []Cluster{
+		{ID: "c1", Hostname: "gpu-a", ClusterType: "16H100", Status: StatusRunning, Tags: []Tag{{Key: "env", Value: "prod"}}},
+		{ID: "c2", Hostname: "gpu-b", ClusterType: "32H100", Status: StatusRunning},
+	}))
+	mockServer.SetHandler(http.MethodGet, "/ssh-keys", jsonHandler(t, []SSHKey{
+		{ID: "k1", Name: "laptop", CreatedAt: early},
+		{ID: "k2", Name: "ci-runner", CreatedAt: late},
+	}))
+	mockServer.SetHandler(http.MethodGet, "/container-deployments", jsonHandler(t, []ContainerDeployment{
+		{Name: "llm-spot", IsSpot: true, CreatedAt: late},
+		{Name: "llm", CreatedAt: early},
+	}))
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	ids := func(t *testing.T, got []string, err error, want ...string) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+
+	t.Run("volumes", func(t *testing.T) {
+		volumes, err := client.Volumes.List(ctx, &VolumeListOptions{Type: VolumeTypeNVMe, Statuses: []string{"detached", "attached"}, CreatedAfter: cutoff})
+		var got []string
+		for _, v := range volumes {
+			got = append(got, v.ID)
+		}
+		ids(t, got, err, "v3")
+	})
+
+	t.Run("clusters", func(t *testing.T) {
+		clusters, err := client.Clusters.List(ctx, &ClusterListOptions{Tags: MatchTags(map[string]string{"env": "prod"})})
+		var got []string
+		for _, c := range clusters {
+			got = append(got, c.ID)
+		}
+		ids(t, got, err, "c1")
+	})
+
+	t.Run("ssh keys", func(t *testing.T) {
+		keys, err := client.SSHKeys.List(ctx, &SSHKeyListOptions{CreatedBefore: cutoff})
+		var got []string
+		for _, k := range keys {
+			got = append(got, k.ID)
+		}
+		ids(t, got, err, "k1")
+	})
+
+	t.Run("container deployments", func(t *testing.T) {
+		deployments, err := client.ContainerDeployments.List(ctx, &ContainerDeploymentListOptions{NamePrefix: "llm", Spot: NoSpot})
+		var got []string
+		for _, d := range deployments {
+			got = append(got, d.Name)
+		}
+		ids(t, got, err, "llm")
+	})
+}
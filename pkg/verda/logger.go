@@ -15,8 +15,15 @@
 package verda
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // Logger interface allows users to plug in their preferred logging library
@@ -67,34 +74,197 @@ func (l *StdLogger) Error(msg string, args ...interface{}) {
 	l.logger.Printf("[ERROR] "+msg, args...)
 }
 
-// SlogLogger wraps Go 1.21+ slog (structured logging)
+// SlogLogger adapts a *slog.Logger to Logger. The SDK recognises it and logs
+// structured attributes straight to the slog logger instead of formatting
+// strings.
 type SlogLogger struct {
-	logger *log.Logger // Using standard log for compatibility
-	debug  bool
+	logger *slog.Logger
 }
 
-// NewSlogLogger creates a structured logger
+// NewSlogLogger creates a structured logger writing text records to stderr
 func NewSlogLogger(debugEnabled bool) *SlogLogger {
-	return &SlogLogger{
-		logger: log.New(os.Stderr, "", log.LstdFlags),
-		debug:  debugEnabled,
+	level := slog.LevelInfo
+	if debugEnabled {
+		level = slog.LevelDebug
 	}
+	return NewSlogLoggerFrom(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+}
+
+// NewSlogLoggerFrom wraps an existing slog logger
+func NewSlogLoggerFrom(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{logger: logger}
+}
+
+// WithSlogLogger makes the client and its middleware log structured records to logger
+func WithSlogLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.Logger = NewSlogLoggerFrom(logger)
+	}
+}
+
+// Slog returns the underlying slog logger
+func (l *SlogLogger) Slog() *slog.Logger {
+	return l.logger
 }
 
 func (l *SlogLogger) Debug(msg string, args ...interface{}) {
-	if l.debug {
-		l.logger.Printf("[DEBUG] "+msg, args...)
+	if l.logger.Enabled(context.Background(), slog.LevelDebug) {
+		l.logger.Debug(fmt.Sprintf(msg, args...))
 	}
 }
 
 func (l *SlogLogger) Info(msg string, args ...interface{}) {
-	l.logger.Printf("[INFO] "+msg, args...)
+	l.logger.Info(fmt.Sprintf(msg, args...))
 }
 
 func (l *SlogLogger) Warn(msg string, args ...interface{}) {
-	l.logger.Printf("[WARN] "+msg, args...)
+	l.logger.Warn(fmt.Sprintf(msg, args...))
 }
 
 func (l *SlogLogger) Error(msg string, args ...interface{}) {
-	l.logger.Printf("[ERROR] "+msg, args...)
+	l.logger.Error(fmt.Sprintf(msg, args...))
+}
+
+// Attribute keys used by every structured log record the SDK writes
+const (
+	LogKeyMethod      = "method"
+	LogKeyPath        = "path"
+	LogKeyQuery       = "query"
+	LogKeyStatus      = "status"
+	LogKeyRequestID   = "request_id"
+	LogKeyDuration    = "duration"
+	LogKeyAttempt     = "attempt"
+	LogKeyMaxAttempts = "max_attempts"
+	LogKeyDelay       = "delay"
+	LogKeyBytes       = "bytes"
+	LogKeyHeaders     = "headers"
+	LogKeyBody        = "body"
+	LogKeyError       = "error"
+)
+
+// RequestIDHeader is the response header carrying the API's request ID
+const RequestIDHeader = "X-Request-Id"
+
+// slogLogger is implemented by loggers that can hand out a *slog.Logger,
+// such as SlogLogger and the zap, zerolog and logr adapters
+type slogLogger interface {
+	Slog() *slog.Logger
+}
+
+// slogFor returns a structured logger for logger. Printf-style loggers are
+// bridged: attributes are appended to the message as key=value pairs.
+func slogFor(logger Logger) *slog.Logger {
+	if sl, ok := logger.(slogLogger); ok {
+		return sl.Slog()
+	}
+	return slog.New(&printfHandler{logger: logger})
+}
+
+// structuredLogger returns the client's Logger as a *slog.Logger
+func (c *Client) structuredLogger() *slog.Logger {
+	if c.slogger == nil {
+		return slogFor(c.Logger)
+	}
+	return c.slogger
+}
+
+// printfHandler is a slog.Handler that writes to a printf-style Logger
+type printfHandler struct {
+	logger Logger
+	attrs  []slog.Attr
+	group  string
+}
+
+func (h *printfHandler) Enabled(_ context.Context, level slog.Level) bool {
+	switch l := h.logger.(type) {
+	case nil, *NoOpLogger:
+		return false
+	case *StdLogger:
+		return level >= slog.LevelInfo || l.debugEnabled
+	default:
+		return true
+	}
+}
+
+func (h *printfHandler) Handle(_ context.Context, record slog.Record) error {
+	var b strings.Builder
+	b.WriteString(record.Message)
+	for _, a := range h.attrs {
+		writeAttr(&b, "", a)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		writeAttr(&b, h.group, a)
+		return true
+	})
+
+	line := b.String()
+	switch {
+	case record.Level >= slog.LevelError:
+		h.logger.Error("%s", line)
+	case record.Level >= slog.LevelWarn:
+		h.logger.Warn("%s", line)
+	case record.Level >= slog.LevelInfo:
+		h.logger.Info("%s", line)
+	default:
+		h.logger.Debug("%s", line)
+	}
+	return nil
+}
+
+func (h *printfHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(slices.Clip(h.attrs), qualify(h.group, attrs)...)
+	return &clone
+}
+
+func (h *printfHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.group = joinKey(h.group, name)
+	return &clone
+}
+
+func qualify(group string, attrs []slog.Attr) []slog.Attr {
+	if group == "" {
+		return attrs
+	}
+	out := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		out[i] = slog.Attr{Key: joinKey(group, a.Key), Value: a.Value}
+	}
+	return out
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// writeAttr appends " key=value", flattening groups into dotted keys
+func writeAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		for _, ga := range a.Value.Group() {
+			writeAttr(b, joinKey(prefix, a.Key), ga)
+		}
+		return
+	}
+
+	value := a.Value.String()
+	if strings.ContainsAny(value, " \t\n\"=") {
+		value = strconv.Quote(value)
+	}
+	fmt.Fprintf(b, " %s=%s", joinKey(prefix, a.Key), value)
+}
+
+// requestContext returns the context of r, or context.Background without one
+func requestContext(r *http.Request) context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	return r.Context()
 }
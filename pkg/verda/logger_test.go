@@ -15,7 +15,16 @@
 package verda
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
 	"testing"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
 )
 
 func TestNoOpLogger(t *testing.T) {
@@ -67,3 +76,94 @@ func TestStdLogger(t *testing.T) {
 		logger.Info("test info message")
 	})
 }
+
+// lineLogger records formatted lines per level
+type lineLogger struct {
+	lines map[string][]string
+}
+
+func newLineLogger() *lineLogger {
+	return &lineLogger{lines: map[string][]string{}}
+}
+
+func (l *lineLogger) record(level, msg string, args ...interface{}) {
+	l.lines[level] = append(l.lines[level], fmt.Sprintf(msg, args...))
+}
+
+func (l *lineLogger) Debug(msg string, args ...interface{}) { l.record("debug", msg, args...) }
+func (l *lineLogger) Info(msg string, args ...interface{})  { l.record("info", msg, args...) }
+func (l *lineLogger) Warn(msg string, args ...interface{})  { l.record("warn", msg, args...) }
+func (l *lineLogger) Error(msg string, args ...interface{}) { l.record("error", msg, args...) }
+
+func TestSlogLogger(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := newRateLimitedTestClient(mockServer, WithSlogLogger(logger))
+
+	if _, err := client.Balance.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("expected JSON records, got %q: %v", line, err)
+		}
+		if record[LogKeyStatus] == float64(http.StatusOK) {
+			found = record[LogKeyMethod] == http.MethodGet && strings.HasSuffix(fmt.Sprint(record[LogKeyPath]), "/balance")
+		}
+	}
+	if !found {
+		t.Errorf("expected a response record with method, path and status, got %s", buf.String())
+	}
+}
+
+func TestSlogFor(t *testing.T) {
+	t.Run("slog loggers are used directly", func(t *testing.T) {
+		logger := NewSlogLogger(true)
+		if slogFor(logger) != logger.Slog() {
+			t.Error("expected the wrapped slog.Logger")
+		}
+	})
+
+	t.Run("printf loggers receive key=value lines", func(t *testing.T) {
+		lines := newLineLogger()
+		slogFor(lines).With(LogKeyMethod, http.MethodGet).Warn("retrying request",
+			slog.String(LogKeyPath, "/v1/instances"),
+			slog.Group(LogKeyHeaders, slog.String("Accept", "application/json")),
+			slog.String(LogKeyError, "connection reset"),
+		)
+
+		want := `retrying request method=GET path=/v1/instances headers.Accept=application/json error="connection reset"`
+		if len(lines.lines["warn"]) != 1 || lines.lines["warn"][0] != want {
+			t.Errorf("expected %q, got %v", want, lines.lines)
+		}
+	})
+
+	t.Run("enabled follows the logger's debug setting", func(t *testing.T) {
+		ctx := context.Background()
+		if slogFor(&NoOpLogger{}).Enabled(ctx, slog.LevelError) {
+			t.Error("expected the no-op logger to be disabled")
+		}
+		if slogFor(NewStdLogger(false)).Enabled(ctx, slog.LevelDebug) {
+			t.Error("expected debug to be disabled")
+		}
+		if !slogFor(NewStdLogger(true)).Enabled(ctx, slog.LevelDebug) {
+			t.Error("expected debug to be enabled")
+		}
+	})
+
+	t.Run("clients build the structured logger once", func(t *testing.T) {
+		client, err := NewClient(WithStaticToken("token"), WithLogger(newLineLogger()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if client.structuredLogger() != client.structuredLogger() {
+			t.Error("expected the same *slog.Logger on every call")
+		}
+	})
+}
@@ -0,0 +1,15 @@
+module github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/logrverda
+
+go 1.25.0
+
+require (
+	github.com/go-logr/logr v1.4.4
+	github.com/verda-cloud/verdacloud-sdk-go v1.5.0
+)
+
+require github.com/go-ozzo/ozzo-validation/v4 v4.3.0 // indirect
+
+// Builds against this checkout of the SDK during development. Users of this
+// module get the SDK version required above; make release sets it to the
+// release being tagged.
+replace github.com/verda-cloud/verdacloud-sdk-go => ../../..
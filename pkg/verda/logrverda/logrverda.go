@@ -0,0 +1,44 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logrverda sends the SDK's structured logs to a logr logger, e.g.
+// the one controller-runtime hands to a reconciler.
+//
+//	client, _ := verda.NewClient(
+//	    verda.WithClientID(id),
+//	    verda.WithClientSecret(secret),
+//	    verda.WithLogger(logrverda.NewLogger(log)),
+//	)
+//
+// slog debug records map to logr verbosity 4 (V(4)); info and above map to
+// V(0), with errors going to the sink's Error method.
+package logrverda
+
+import (
+	"log/slog"
+
+	"github.com/go-logr/logr"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// NewLogger returns a verda.Logger that writes structured records to logger
+func NewLogger(logger logr.Logger) *verda.SlogLogger {
+	return verda.NewSlogLoggerFrom(slog.New(NewHandler(logger)))
+}
+
+// NewHandler returns a slog.Handler that writes to logger
+func NewHandler(logger logr.Logger) slog.Handler {
+	return logr.ToSlogHandler(logger)
+}
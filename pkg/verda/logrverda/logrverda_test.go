@@ -0,0 +1,57 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logrverda
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func TestNewLogger(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+	config := testutil.NewTestClientConfig(mockServer)
+
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: 4})
+
+	client, err := verda.NewClient(
+		verda.WithBaseURL(config.BaseURL),
+		verda.WithClientID(config.ClientID),
+		verda.WithClientSecret(config.ClientSecret),
+		verda.WithLogger(NewLogger(logger)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Balance.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, line := range lines {
+		if strings.Contains(line, `"`+verda.LogKeyStatus+`"=200`) {
+			return
+		}
+	}
+	t.Errorf("expected a response record with the status, got %q", lines)
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestMetrics describes a single HTTP attempt made by the client
+type RequestMetrics struct {
+	Method string
+	// PathTemplate is the API path with IDs collapsed, e.g. "/instances/{id}"
+	PathTemplate string
+	// StatusCode is 0 when the request failed before a response arrived
+	StatusCode int
+	// Duration runs from sending the request until the response body is closed
+	Duration      time.Duration
+	BytesSent     int64
+	BytesReceived int64
+	// Err is the transport error, if any
+	Err error
+}
+
+// MetricsCollector receives client-side measurements. Implementations must be
+// safe for concurrent use and should not block.
+type MetricsCollector interface {
+	// ObserveRequest is called once per HTTP attempt, retries included
+	ObserveRequest(m RequestMetrics)
+	// ObserveRetry is called each time Client.Do schedules a retry
+	ObserveRetry(method, pathTemplate string)
+	// ObserveTokenRefresh is called after each token request
+	ObserveTokenRefresh(grantType string, success bool)
+}
+
+// WithMetrics reports request, retry and token metrics to collector
+func WithMetrics(collector MetricsCollector) ClientOption {
+	return func(c *Client) {
+		c.Metrics = collector
+	}
+}
+
+// pathWords are the fixed path segments of the Verda API. Any other segment
+// after the first is an ID or name and is collapsed by PathTemplate.
+var pathWords = map[string]bool{
+	"balance": true, "cluster": true, "cluster-availability": true, "cluster-types": true,
+	"clusters": true, "container-deployments": true, "container-registry-credentials": true,
+	"container-types": true, "environment-variables": true, "file-secrets": true,
+	"images": true, "instance-availability": true, "instance-types": true, "instances": true,
+	"job-deployments": true, "locations": true, "long-term": true, "oauth2": true,
+	"pause": true, "periods": true, "price-history": true, "purge-queue": true,
+	"replicas": true, "restart": true, "resume": true, "scaling": true, "scripts": true,
+	"secrets": true, "serverless-compute-resources": true, "ssh-keys": true, "status": true,
+	"tags": true, "token": true, "trash": true, "volume-types": true, "volumes": true,
+}
+
+// PathTemplate collapses IDs and names in an API path to "{id}" so metrics
+// keep a bounded number of label values: "/instances/abc/tags/env" becomes
+// "/instances/{id}/tags/{id}". The first segment is always kept.
+func PathTemplate(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if !pathWords[segments[i]] {
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// meteredBody counts the bytes read from a response body and reports the
+// attempt to the collector when the body is closed
+type meteredBody struct {
+	io.ReadCloser
+	n       int64
+	once    sync.Once
+	onClose func(n int64)
+}
+
+func (b *meteredBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *meteredBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.onClose(b.n) })
+	return err
+}
+
+// observeRequest reports one HTTP attempt. A nil resp means a transport error;
+// otherwise the measurement is taken once the body has been closed.
+func (c *Client) observeRequest(req *http.Request, resp *http.Response, err error, start time.Time) {
+	if c.Metrics == nil {
+		return
+	}
+
+	m := RequestMetrics{
+		Method:       req.Method,
+		PathTemplate: PathTemplate(c.relativePath(req.URL.Path)),
+		BytesSent:    max(req.ContentLength, 0),
+		Err:          err,
+	}
+	if resp == nil {
+		m.Duration = time.Since(start)
+		c.Metrics.ObserveRequest(m)
+		return
+	}
+
+	m.StatusCode = resp.StatusCode
+	resp.Body = &meteredBody{
+		ReadCloser: resp.Body,
+		onClose: func(n int64) {
+			m.Duration = time.Since(start)
+			m.BytesReceived = n
+			c.Metrics.ObserveRequest(m)
+		},
+	}
+}
+
+func (c *Client) observeRetry(req *http.Request) {
+	if c.Metrics != nil {
+		c.Metrics.ObserveRetry(req.Method, PathTemplate(c.relativePath(req.URL.Path)))
+	}
+}
+
+func (c *Client) observeTokenRefresh(grantType string, success bool) {
+	if c.Metrics != nil {
+		c.Metrics.ObserveTokenRefresh(grantType, success)
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultMetricsNamespace prefixes every metric name of PrometheusMetrics
+const DefaultMetricsNamespace = "verda_sdk"
+
+// DefaultLatencyBuckets are the request duration histogram buckets in seconds
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// PrometheusMetricsConfig configures PrometheusMetrics
+type PrometheusMetricsConfig struct {
+	// Namespace prefixes metric names. Defaults to "verda_sdk".
+	Namespace string
+	// Buckets are the latency histogram upper bounds in seconds. Defaults to
+	// DefaultLatencyBuckets.
+	Buckets []float64
+}
+
+// PrometheusMetrics is a MetricsCollector that keeps its measurements in
+// memory and serves them in the Prometheus text exposition format. Mount it
+// on your metrics endpoint or call WriteTo from an existing handler.
+//
+// It exposes (with the default namespace):
+//
+//	verda_sdk_requests_total{method,path,status}
+//	verda_sdk_request_duration_seconds{method,path,status} (histogram)
+//	verda_sdk_request_bytes_total{method,path}
+//	verda_sdk_response_bytes_total{method,path}
+//	verda_sdk_retries_total{method,path}
+//	verda_sdk_token_refreshes_total{grant_type,result}
+type PrometheusMetrics struct {
+	namespace string
+	buckets   []float64
+
+	mu            sync.Mutex
+	requests      map[requestLabels]*latencyHistogram
+	bytesSent     map[routeLabels]int64
+	bytesReceived map[routeLabels]int64
+	retries       map[routeLabels]int64
+	tokens        map[tokenLabels]int64
+}
+
+type routeLabels struct {
+	method string
+	path   string
+}
+
+type requestLabels struct {
+	routeLabels
+	status string
+}
+
+type tokenLabels struct {
+	grantType string
+	result    string
+}
+
+type latencyHistogram struct {
+	counts []uint64 // per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheusMetrics creates an empty collector, filling in defaults for zero fields
+func NewPrometheusMetrics(config PrometheusMetricsConfig) *PrometheusMetrics {
+	namespace := config.Namespace
+	if namespace == "" {
+		namespace = DefaultMetricsNamespace
+	}
+	buckets := append([]float64(nil), config.Buckets...)
+	if len(buckets) == 0 {
+		buckets = append(buckets, DefaultLatencyBuckets...)
+	}
+	sort.Float64s(buckets)
+
+	return &PrometheusMetrics{
+		namespace:     namespace,
+		buckets:       buckets,
+		requests:      make(map[requestLabels]*latencyHistogram),
+		bytesSent:     make(map[routeLabels]int64),
+		bytesReceived: make(map[routeLabels]int64),
+		retries:       make(map[routeLabels]int64),
+		tokens:        make(map[tokenLabels]int64),
+	}
+}
+
+func (p *PrometheusMetrics) ObserveRequest(m RequestMetrics) {
+	route := routeLabels{method: m.Method, path: m.PathTemplate}
+	status := "error"
+	if m.StatusCode > 0 {
+		status = strconv.Itoa(m.StatusCode)
+	}
+	seconds := m.Duration.Seconds()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h, ok := p.requests[requestLabels{routeLabels: route, status: status}]
+	if !ok {
+		h = &latencyHistogram{counts: make([]uint64, len(p.buckets))}
+		p.requests[requestLabels{routeLabels: route, status: status}] = h
+	}
+	for i, bound := range p.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+
+	p.bytesSent[route] += m.BytesSent
+	p.bytesReceived[route] += m.BytesReceived
+}
+
+func (p *PrometheusMetrics) ObserveRetry(method, pathTemplate string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retries[routeLabels{method: method, path: pathTemplate}]++
+}
+
+func (p *PrometheusMetrics) ObserveTokenRefresh(grantType string, success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tokens[tokenLabels{grantType: grantType, result: result}]++
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format
+func (p *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format
+func (p *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	name := func(metric string) string { return p.namespace + "_" + metric }
+
+	// Requests: counter and histogram share the same label sets
+	requestKeys := make([]requestLabels, 0, len(p.requests))
+	for k := range p.requests {
+		requestKeys = append(requestKeys, k)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		a, b := requestKeys[i], requestKeys[j]
+		if a.routeLabels != b.routeLabels {
+			return a.routeLabels.less(b.routeLabels)
+		}
+		return a.status < b.status
+	})
+
+	writeHeader(cw, name("requests_total"), "counter", "HTTP requests sent by the SDK, retries included.")
+	for _, k := range requestKeys {
+		fmt.Fprintf(cw, "%s{%s} %d\n", name("requests_total"), k.labels(), p.requests[k].count)
+	}
+
+	writeHeader(cw, name("request_duration_seconds"), "histogram", "Latency of HTTP requests sent by the SDK.")
+	for _, k := range requestKeys {
+		h := p.requests[k]
+		var cumulative uint64
+		for i, bound := range p.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(cw, "%s_bucket{%s,le=\"%s\"} %d\n", name("request_duration_seconds"), k.labels(), formatFloat(bound), cumulative)
+		}
+		fmt.Fprintf(cw, "%s_bucket{%s,le=\"+Inf\"} %d\n", name("request_duration_seconds"), k.labels(), h.count)
+		fmt.Fprintf(cw, "%s_sum{%s} %s\n", name("request_duration_seconds"), k.labels(), formatFloat(h.sum))
+		fmt.Fprintf(cw, "%s_count{%s} %d\n", name("request_duration_seconds"), k.labels(), h.count)
+	}
+
+	writeRouteCounter(cw, name("request_bytes_total"), "Request body bytes sent by the SDK.", p.bytesSent)
+	writeRouteCounter(cw, name("response_bytes_total"), "Response body bytes received by the SDK.", p.bytesReceived)
+	writeRouteCounter(cw, name("retries_total"), "Retries scheduled by the SDK.", p.retries)
+
+	tokenKeys := make([]tokenLabels, 0, len(p.tokens))
+	for k := range p.tokens {
+		tokenKeys = append(tokenKeys, k)
+	}
+	sort.Slice(tokenKeys, func(i, j int) bool {
+		if tokenKeys[i].grantType != tokenKeys[j].grantType {
+			return tokenKeys[i].grantType < tokenKeys[j].grantType
+		}
+		return tokenKeys[i].result < tokenKeys[j].result
+	})
+	writeHeader(cw, name("token_refreshes_total"), "counter", "OAuth token requests made by the SDK.")
+	for _, k := range tokenKeys {
+		fmt.Fprintf(cw, "%s{grant_type=\"%s\",result=\"%s\"} %d\n",
+			name("token_refreshes_total"), escapeLabel(k.grantType), k.result, p.tokens[k])
+	}
+
+	if err := cw.w.Flush(); err != nil {
+		return cw.n, err
+	}
+	return cw.n, cw.err
+}
+
+func (r routeLabels) less(o routeLabels) bool {
+	if r.path != o.path {
+		return r.path < o.path
+	}
+	return r.method < o.method
+}
+
+func (r routeLabels) labels() string {
+	return fmt.Sprintf("method=\"%s\",path=\"%s\"", escapeLabel(r.method), escapeLabel(r.path))
+}
+
+func (r requestLabels) labels() string {
+	return fmt.Sprintf("%s,status=\"%s\"", r.routeLabels.labels(), r.status)
+}
+
+func writeHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func writeRouteCounter(w io.Writer, name, help string, values map[routeLabels]int64) {
+	keys := make([]routeLabels, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+
+	writeHeader(w, name, "counter", help)
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s{%s} %d\n", name, k.labels(), values[k])
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// countingWriter tracks bytes written and the first write error
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+// recordingCollector keeps every observation for inspection
+type recordingCollector struct {
+	mu       sync.Mutex
+	requests []RequestMetrics
+	retries  []string
+	tokens   []string
+}
+
+func (r *recordingCollector) ObserveRequest(m RequestMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, m)
+}
+
+func (r *recordingCollector) ObserveRetry(method, pathTemplate string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries = append(r.retries, method+" "+pathTemplate)
+}
+
+func (r *recordingCollector) ObserveTokenRefresh(grantType string, success bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if success {
+		r.tokens = append(r.tokens, grantType)
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/instances":                             "/instances",
+		"/instances/abc-123":                     "/instances/{id}",
+		"/instances/abc-123/tags/env":            "/instances/{id}/tags/{id}",
+		"/instance-types/1V100.6V?currency=eur":  "/instance-types/{id}",
+		"/instance-types/price-history":          "/instance-types/price-history",
+		"/images/cluster":                        "/images/cluster",
+		"/volumes/trash":                         "/volumes/trash",
+		"/long-term/periods/instances":           "/long-term/periods/instances",
+		"/container-deployments/my-app/scaling":  "/container-deployments/{id}/scaling",
+		"/job-deployments/nightly/status":        "/job-deployments/{id}/status",
+		"/ssh-keys/8f0c6a8e-4d9b-4b71-9f43-12ab": "/ssh-keys/{id}",
+		"/":                                      "/",
+	}
+	for path, want := range tests {
+		if got := PathTemplate(path); got != want {
+			t.Errorf("PathTemplate(%q) = %q, expected %q", path, got, want)
+		}
+	}
+}
+
+func TestClient_Metrics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requests, retries and token refreshes are observed", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/instances/inst_1", failingHandler(&calls, 1, http.StatusServiceUnavailable, nil))
+
+		collector := &recordingCollector{}
+		client := newRateLimitedTestClient(mockServer,
+			WithMetrics(collector),
+			WithRetryPolicy(&RetryPolicy{MaxRetries: 2, InitialDelay: time.Millisecond}),
+		)
+
+		if _, err := client.Instances.GetByID(ctx, "inst_1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(collector.requests) != 2 {
+			t.Fatalf("expected 2 observed attempts, got %d", len(collector.requests))
+		}
+		first, second := collector.requests[0], collector.requests[1]
+		if first.PathTemplate != "/instances/{id}" || first.Method != http.MethodGet {
+			t.Errorf("unexpected labels: %s %s", first.Method, first.PathTemplate)
+		}
+		if first.StatusCode != http.StatusServiceUnavailable || second.StatusCode != http.StatusOK {
+			t.Errorf("unexpected statuses: %d, %d", first.StatusCode, second.StatusCode)
+		}
+		if second.BytesReceived == 0 || second.Duration <= 0 {
+			t.Errorf("expected bytes and duration to be measured, got %+v", second)
+		}
+		if len(collector.retries) != 1 || collector.retries[0] != "GET /instances/{id}" {
+			t.Errorf("unexpected retries: %v", collector.retries)
+		}
+		if len(collector.tokens) != 1 || collector.tokens[0] != "client_credentials" {
+			t.Errorf("unexpected token refreshes: %v", collector.tokens)
+		}
+	})
+
+	t.Run("request bytes are counted", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		mockServer.SetHandler(http.MethodPost, "/things", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		collector := &recordingCollector{}
+		client := newRateLimitedTestClient(mockServer, WithMetrics(collector))
+		if _, err := postRequestAllowEmptyResponse(ctx, client, "/things", map[string]string{"name": "x"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(collector.requests) != 1 || collector.requests[0].BytesSent != int64(len(`{"name":"x"}`)) {
+			t.Errorf("unexpected observations: %+v", collector.requests)
+		}
+	})
+
+	t.Run("transport errors are observed without a status", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		collector := &recordingCollector{}
+		client := newRateLimitedTestClient(mockServer, WithMetrics(collector))
+
+		if _, err := client.Balance.Get(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mockServer.Close()
+		if _, err := client.Balance.Get(ctx); err == nil {
+			t.Fatal("expected a transport error")
+		}
+
+		last := collector.requests[len(collector.requests)-1]
+		if last.StatusCode != 0 || last.Err == nil {
+			t.Errorf("expected a failed attempt, got %+v", last)
+		}
+	})
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	p := NewPrometheusMetrics(PrometheusMetricsConfig{Buckets: []float64{0.1, 1}})
+
+	p.ObserveRequest(RequestMetrics{Method: "GET", PathTemplate: "/instances/{id}", StatusCode: 200, Duration: 50 * time.Millisecond, BytesReceived: 100})
+	p.ObserveRequest(RequestMetrics{Method: "GET", PathTemplate: "/instances/{id}", StatusCode: 200, Duration: 500 * time.Millisecond, BytesReceived: 20})
+	p.ObserveRequest(RequestMetrics{Method: "POST", PathTemplate: "/volumes", Duration: 2 * time.Second, BytesSent: 42})
+	p.ObserveRetry("POST", "/volumes")
+	p.ObserveTokenRefresh("client_credentials", true)
+	p.ObserveTokenRefresh("refresh_token", false)
+
+	recorder := httptest.NewRecorder()
+	p.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := recorder.Body.String()
+
+	if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	expected := []string{
+		"# TYPE verda_sdk_requests_total counter",
+		`verda_sdk_requests_total{method="GET",path="/instances/{id}",status="200"} 2`,
+		`verda_sdk_requests_total{method="POST",path="/volumes",status="error"} 1`,
+		"# TYPE verda_sdk_request_duration_seconds histogram",
+		`verda_sdk_request_duration_seconds_bucket{method="GET",path="/instances/{id}",status="200",le="0.1"} 1`,
+		`verda_sdk_request_duration_seconds_bucket{method="GET",path="/instances/{id}",status="200",le="1"} 2`,
+		`verda_sdk_request_duration_seconds_bucket{method="GET",path="/instances/{id}",status="200",le="+Inf"} 2`,
+		`verda_sdk_request_duration_seconds_sum{method="GET",path="/instances/{id}",status="200"} 0.55`,
+		`verda_sdk_request_duration_seconds_bucket{method="POST",path="/volumes",status="error",le="1"} 0`,
+		`verda_sdk_request_bytes_total{method="POST",path="/volumes"} 42`,
+		`verda_sdk_response_bytes_total{method="GET",path="/instances/{id}"} 120`,
+		`verda_sdk_retries_total{method="POST",path="/volumes"} 1`,
+		`verda_sdk_token_refreshes_total{grant_type="client_credentials",result="success"} 1`,
+		`verda_sdk_token_refreshes_total{grant_type="refresh_token",result="failure"} 1`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("expected output to contain %q\n%s", line, out)
+		}
+	}
+}
@@ -16,14 +16,18 @@ package verda
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"math"
+	"net"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 )
@@ -57,15 +61,11 @@ type ResponseHandler func(ctx *ResponseContext) error
 func AuthenticationMiddleware() RequestMiddleware {
 	return func(next RequestHandler) RequestHandler {
 		return func(ctx *RequestContext) error {
-			bearerToken, err := ctx.Client.Auth.GetBearerToken()
+			bearerToken, err := ctx.Client.authorization(requestContext(ctx.Request))
 			if err != nil {
 				return fmt.Errorf("failed to get authentication token: %w", err)
 			}
 
-			if bearerToken == "" {
-				return fmt.Errorf("empty authentication token")
-			}
-
 			ctx.Headers.Set("Authorization", bearerToken)
 
 			return next(ctx)
@@ -76,7 +76,7 @@ func AuthenticationMiddleware() RequestMiddleware {
 func ContentTypeMiddleware(contentType string) RequestMiddleware {
 	return func(next RequestHandler) RequestHandler {
 		return func(ctx *RequestContext) error {
-			if ctx.Body != nil {
+			if ctx.Body != nil && !headerOverridden(ctx, "Content-Type") {
 				ctx.Headers.Set("Content-Type", contentType)
 			}
 			return next(ctx)
@@ -99,18 +99,25 @@ func cryptoRandFloat64() float64 {
 }
 
 func LoggingMiddleware(logger Logger) RequestMiddleware {
+	log := slogFor(logger)
 	return func(next RequestHandler) RequestHandler {
 		return func(ctx *RequestContext) error {
+			reqCtx := requestContext(ctx.Request)
 			start := time.Now()
-			logger.Debug("Starting %s request to %s", ctx.Method, ctx.Path)
+			log.LogAttrs(reqCtx, slog.LevelDebug, "request started",
+				slog.String(LogKeyMethod, ctx.Method), slog.String(LogKeyPath, ctx.Path))
 
 			err := next(ctx)
 
-			duration := time.Since(start)
+			attrs := []slog.Attr{
+				slog.String(LogKeyMethod, ctx.Method),
+				slog.String(LogKeyPath, ctx.Path),
+				slog.Duration(LogKeyDuration, time.Since(start)),
+			}
 			if err != nil {
-				logger.Debug("Request %s %s failed after %v: %v", ctx.Method, ctx.Path, duration, err)
+				log.LogAttrs(reqCtx, slog.LevelDebug, "request middleware failed", append(attrs, slog.Any(LogKeyError, err))...)
 			} else {
-				logger.Debug("Request %s %s completed in %v", ctx.Method, ctx.Path, duration)
+				log.LogAttrs(reqCtx, slog.LevelDebug, "request prepared", attrs...)
 			}
 
 			return err
@@ -118,16 +125,29 @@ func LoggingMiddleware(logger Logger) RequestMiddleware {
 	}
 }
 
+// headerOverridden reports whether the call set name through the Header
+// request option
+func headerOverridden(ctx *RequestContext, name string) bool {
+	return requestOptionsFrom(requestContext(ctx.Request)).header.Get(name) != ""
+}
+
 func UserAgentMiddleware(userAgent string) RequestMiddleware {
 	return func(next RequestHandler) RequestHandler {
 		return func(ctx *RequestContext) error {
-			ctx.Headers.Set("User-Agent", userAgent)
+			if !headerOverridden(ctx, "User-Agent") {
+				ctx.Headers.Set("User-Agent", userAgent)
+			}
 			return next(ctx)
 		}
 	}
 }
 
+// ExponentialBackoffRetryMiddleware retries the rest of the request middleware chain.
+// It runs before the HTTP call is made, so it never replays the request itself.
+//
+// Deprecated: Use WithRetryPolicy, which retries the HTTP exchange in Client.Do.
 func ExponentialBackoffRetryMiddleware(maxRetries int, initialDelay time.Duration, logger Logger) RequestMiddleware {
+	log := slogFor(logger)
 	const maxDelay = 30 * time.Second
 	const jitterPercent = 0.5
 
@@ -135,6 +155,14 @@ func ExponentialBackoffRetryMiddleware(maxRetries int, initialDelay time.Duratio
 		return func(ctx *RequestContext) error {
 			var lastErr error
 
+			maxRetries := maxRetries
+			if options := requestOptionsFrom(requestContext(ctx.Request)); options.retrySet {
+				maxRetries = 0
+				if options.retry != nil {
+					maxRetries = options.retry.MaxRetries
+				}
+			}
+
 			for attempt := 0; attempt <= maxRetries; attempt++ {
 				if attempt > 0 {
 					// Exponential backoff: initialDelay * 2^(attempt-1), capped at 30s
@@ -145,9 +173,19 @@ func ExponentialBackoffRetryMiddleware(maxRetries int, initialDelay time.Duratio
 					jitter := (cryptoRandFloat64()*2 - 1) * jitterPercent
 					actualDelay := time.Duration(float64(cappedDelay) * (1 + jitter))
 
-					logger.Debug("Retrying request %s %s (attempt %d/%d) after %v",
-						ctx.Method, ctx.Path, attempt+1, maxRetries+1, actualDelay)
-					time.Sleep(actualDelay)
+					log.LogAttrs(requestContext(ctx.Request), slog.LevelDebug, "retrying request",
+						slog.String(LogKeyMethod, ctx.Method),
+						slog.String(LogKeyPath, ctx.Path),
+						slog.Int(LogKeyAttempt, attempt+1),
+						slog.Int(LogKeyMaxAttempts, maxRetries+1),
+						slog.Duration(LogKeyDelay, actualDelay))
+					if ctx.Request != nil {
+						if err := sleepContext(ctx.Request.Context(), actualDelay); err != nil {
+							return fmt.Errorf("request failed after %d retries: %w", attempt-1, err)
+						}
+					} else {
+						time.Sleep(actualDelay)
+					}
 				}
 
 				lastErr = next(ctx)
@@ -156,7 +194,10 @@ func ExponentialBackoffRetryMiddleware(maxRetries int, initialDelay time.Duratio
 				}
 
 				if !shouldRetry(lastErr) {
-					logger.Debug("Request %s %s failed with non-retryable error: %v", ctx.Method, ctx.Path, lastErr)
+					log.LogAttrs(requestContext(ctx.Request), slog.LevelDebug, "request failed with non-retryable error",
+						slog.String(LogKeyMethod, ctx.Method),
+						slog.String(LogKeyPath, ctx.Path),
+						slog.Any(LogKeyError, lastErr))
 					break
 				}
 			}
@@ -166,88 +207,41 @@ func ExponentialBackoffRetryMiddleware(maxRetries int, initialDelay time.Duratio
 	}
 }
 
-// shouldRetry decides if we should retry based on status code - never retry auth/client errors
+// shouldRetry decides from the type of err whether to retry: API errors by
+// status code, network failures always, and never once the context is done
+// or the circuit is open
 func shouldRetry(err error) bool {
-	if err == nil {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCircuitOpen) {
 		return false
 	}
 
 	var apiErr *APIError
 	if errors.As(err, &apiErr) {
-		statusCode := apiErr.StatusCode
-
-		switch statusCode {
-		case http.StatusInternalServerError,
-			http.StatusBadGateway,
-			http.StatusServiceUnavailable,
-			http.StatusGatewayTimeout,
-			http.StatusTooManyRequests,
-			http.StatusRequestTimeout:
+		switch apiErr.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests:
 			return true
-		case http.StatusBadRequest,
-			http.StatusUnauthorized,
-			http.StatusForbidden,
-			http.StatusNotFound:
-			return false
 		default:
-			if statusCode >= 500 && statusCode < 600 {
-				return true
-			}
-			if statusCode >= 400 && statusCode < 500 {
-				return false
-			}
-		}
-	}
-
-	errStr := strings.ToLower(err.Error())
-
-	nonRetryablePatterns := []string{
-		"authentication",
-		"unauthorized",
-		"forbidden",
-		"not found",
-		"invalid",
-		"bad request",
-	}
-	for _, pattern := range nonRetryablePatterns {
-		if strings.Contains(errStr, pattern) {
-			return false
+			return apiErr.StatusCode >= 500 && apiErr.StatusCode < 600
 		}
 	}
 
-	retryablePatterns := []string{
-		"timeout",
-		"connection",
-		"temporary",
-		"rate limit",
-		"too many requests",
-	}
-	for _, pattern := range retryablePatterns {
-		if strings.Contains(errStr, pattern) {
-			return true
-		}
+	// *url.Error is itself a net.Error, so look at what it wraps
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
 	}
-
-	return false
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
 }
 
 func ErrorHandlingMiddleware() ResponseMiddleware {
 	return func(next ResponseHandler) ResponseHandler {
 		return func(ctx *ResponseContext) error {
 			if ctx.StatusCode < 200 || ctx.StatusCode >= 300 {
-				var apiError APIError
-				if len(ctx.Body) > 0 {
-					apiError = APIError{
-						StatusCode: ctx.StatusCode,
-						Message:    string(ctx.Body),
-					}
-				} else {
-					apiError = APIError{
-						StatusCode: ctx.StatusCode,
-						Message:    http.StatusText(ctx.StatusCode),
-					}
+				// Keep an error already parsed from the same body
+				if _, ok := ctx.Error.(*APIError); !ok {
+					ctx.Error = newAPIError(ctx.Response, ctx.Body)
 				}
-				ctx.Error = &apiError
 			}
 
 			return next(ctx)
@@ -256,33 +250,38 @@ func ErrorHandlingMiddleware() ResponseMiddleware {
 }
 
 func ResponseLoggingMiddleware(logger Logger) ResponseMiddleware {
+	log := slogFor(logger)
 	return func(next ResponseHandler) ResponseHandler {
 		return func(ctx *ResponseContext) error {
-			logger.Debug("Response for %s %s: Status %d, Body length: %d bytes",
-				ctx.Request.Method, ctx.Request.Path, ctx.StatusCode, len(ctx.Body))
-
+			attrs := responseLogAttrs(ctx)
 			if ctx.Error != nil {
-				logger.Debug("Response error: %v", ctx.Error)
+				attrs = append(attrs, slog.Any(LogKeyError, ctx.Error))
 			}
+			log.LogAttrs(responseRequestContext(ctx), slog.LevelDebug, "response received", attrs...)
 
 			return next(ctx)
 		}
 	}
 }
 
-// MetricsMiddleware is a placeholder for collecting request/response metrics
+// MetricsMiddleware logs a debug line per response.
+//
+// Deprecated: use WithMetrics with a MetricsCollector such as PrometheusMetrics.
 func MetricsMiddleware(logger Logger) ResponseMiddleware {
+	log := slogFor(logger)
 	return func(next ResponseHandler) ResponseHandler {
 		return func(ctx *ResponseContext) error {
-			logger.Debug("Metrics: %s %s -> %d (%d bytes)",
-				ctx.Request.Method, ctx.Request.Path, ctx.StatusCode, len(ctx.Body))
+			log.LogAttrs(responseRequestContext(ctx), slog.LevelDebug, "metrics", responseLogAttrs(ctx)...)
 
 			return next(ctx)
 		}
 	}
 }
 
-// CacheMiddleware is a placeholder for response caching
+// CacheMiddleware does nothing and is kept for compatibility.
+//
+// Deprecated: use WithResponseCache. Middleware cannot short-circuit a request,
+// so caching happens in Client.Do instead.
 func CacheMiddleware() ResponseMiddleware {
 	return func(next ResponseHandler) ResponseHandler {
 		return func(ctx *ResponseContext) error {
@@ -293,6 +292,7 @@ func CacheMiddleware() ResponseMiddleware {
 
 // DebugLoggingMiddleware logs full request details - redacts auth headers and skips token endpoints
 func DebugLoggingMiddleware(logger Logger) RequestMiddleware {
+	log := slogFor(logger)
 	return func(next RequestHandler) RequestHandler {
 		return func(ctx *RequestContext) error {
 			// Don't log sensitive token refresh endpoints
@@ -300,89 +300,52 @@ func DebugLoggingMiddleware(logger Logger) RequestMiddleware {
 				return next(ctx)
 			}
 
-			logger.Info("=== API Request ===")
-			logger.Info("Method: %s", ctx.Method)
-			logger.Info("Path: %s", ctx.Path)
-
-			if len(ctx.Query) > 0 {
-				logger.Info("Query Parameters: %s", ctx.Query.Encode())
+			attrs := []slog.Attr{
+				slog.String(LogKeyMethod, ctx.Method),
+				slog.String(LogKeyPath, ctx.Path),
 			}
-
-			logger.Info("Headers:")
-			for name, values := range ctx.Headers {
-				if name == "Authorization" {
-					logger.Info("  %s: [REDACTED]", name)
-				} else {
-					for _, value := range values {
-						logger.Info("  %s: %s", name, value)
-					}
-				}
+			if len(ctx.Query) > 0 {
+				attrs = append(attrs, slog.String(LogKeyQuery, ctx.Query.Encode()))
 			}
+			attrs = append(attrs, headerLogAttr(ctx.Headers))
 
 			// Log request body if present
 			if ctx.Request != nil && ctx.Request.Body != nil {
-				// Read the body
 				bodyBytes, err := io.ReadAll(ctx.Request.Body)
 				if err != nil {
-					logger.Info("Request Body: [error reading body: %v]", err)
+					attrs = append(attrs, slog.Any(LogKeyError, fmt.Errorf("reading request body: %w", err)))
 				} else {
 					// Restore the body for the actual request
 					ctx.Request.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-
-					if len(bodyBytes) > 0 {
-						bodyStr := string(bodyBytes)
-						if len(bodyStr) > 1000 {
-							logger.Info("Request Body (truncated): %s...", bodyStr[:1000])
-						} else {
-							logger.Info("Request Body: %s", bodyStr)
-						}
-					} else {
-						logger.Info("Request Body: [empty]")
-					}
+					attrs = append(attrs, bodyLogAttr(bodyBytes))
 				}
-			} else {
-				logger.Info("Request Body: [none]")
 			}
 
+			log.LogAttrs(requestContext(ctx.Request), slog.LevelInfo, "API request", attrs...)
+
 			return next(ctx)
 		}
 	}
 }
 
 func DebugResponseLoggingMiddleware(logger Logger) ResponseMiddleware {
+	log := slogFor(logger)
 	return func(next ResponseHandler) ResponseHandler {
 		return func(ctx *ResponseContext) error {
 			if ctx.Request != nil && strings.Contains(ctx.Request.Path, "/token") {
 				return next(ctx)
 			}
 
-			logger.Info("=== API Response ===")
-			logger.Info("Status Code: %d", ctx.StatusCode)
-
+			attrs := responseLogAttrs(ctx)
 			if ctx.Response != nil {
-				logger.Info("Response Headers:")
-				for name, values := range ctx.Response.Header {
-					for _, value := range values {
-						logger.Info("  %s: %s", name, value)
-					}
-				}
+				attrs = append(attrs, headerLogAttr(ctx.Response.Header))
 			}
-
-			if len(ctx.Body) > 0 {
-				bodyStr := string(ctx.Body)
-				if len(bodyStr) > 1000 {
-					logger.Info("Response Body (truncated): %s...", bodyStr[:1000])
-				} else {
-					logger.Info("Response Body: %s", bodyStr)
-				}
-			} else {
-				logger.Info("Response Body: [empty]")
-			}
-
+			attrs = append(attrs, bodyLogAttr(ctx.Body))
 			if ctx.Error != nil {
-				logger.Info("Error: %v", ctx.Error)
+				attrs = append(attrs, slog.Any(LogKeyError, ctx.Error))
 			}
-			logger.Info("==================")
+
+			log.LogAttrs(responseRequestContext(ctx), slog.LevelInfo, "API response", attrs...)
 
 			return next(ctx)
 		}
@@ -395,3 +358,60 @@ func AddDetailedDebugLogging(client *Client) {
 	client.AddRequestMiddleware(DebugLoggingMiddleware(client.Logger))
 	client.AddResponseMiddleware(DebugResponseLoggingMiddleware(client.Logger))
 }
+
+// maxLoggedBody caps how much of a request or response body debug logging records
+const maxLoggedBody = 1000
+
+// responseLogAttrs returns the attributes identifying a response
+func responseLogAttrs(ctx *ResponseContext) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 6)
+	if ctx.Request != nil {
+		attrs = append(attrs,
+			slog.String(LogKeyMethod, ctx.Request.Method),
+			slog.String(LogKeyPath, ctx.Request.Path))
+	}
+	attrs = append(attrs,
+		slog.Int(LogKeyStatus, ctx.StatusCode),
+		slog.Int(LogKeyBytes, len(ctx.Body)))
+	if ctx.Response != nil {
+		if id := ctx.Response.Header.Get(RequestIDHeader); id != "" {
+			attrs = append(attrs, slog.String(LogKeyRequestID, id))
+		}
+	}
+	return attrs
+}
+
+// responseRequestContext returns the context of the request behind ctx
+func responseRequestContext(ctx *ResponseContext) context.Context {
+	if ctx.Request == nil {
+		return context.Background()
+	}
+	return requestContext(ctx.Request.Request)
+}
+
+// headerLogAttr groups headers under "headers", redacting credentials
+func headerLogAttr(header http.Header) slog.Attr {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attrs := make([]any, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if name == "Authorization" {
+			value = "[REDACTED]"
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.Group(LogKeyHeaders, attrs...)
+}
+
+// bodyLogAttr records a body, truncated to maxLoggedBody bytes
+func bodyLogAttr(body []byte) slog.Attr {
+	if len(body) > maxLoggedBody {
+		return slog.String(LogKeyBody, string(body[:maxLoggedBody])+"...")
+	}
+	return slog.String(LogKeyBody, string(body))
+}
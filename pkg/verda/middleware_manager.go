@@ -18,11 +18,12 @@ import (
 	"sync"
 )
 
-// Middleware manages request and response middleware chains with thread-safe operations
+// Middleware manages request, response and exchange middleware chains with thread-safe operations
 type Middleware struct {
 	mu                 sync.RWMutex
 	requestMiddleware  []RequestMiddleware
 	responseMiddleware []ResponseMiddleware
+	exchangeMiddleware []ExchangeMiddleware
 }
 
 // NewMiddleware creates a new Middleware manager with optional default middleware
@@ -72,6 +73,13 @@ func (m *Middleware) Snapshot() ([]RequestMiddleware, []ResponseMiddleware) {
 	return requestCopy, responseCopy
 }
 
+// ExchangeSnapshot returns a thread-safe copy of the exchange middleware chain
+func (m *Middleware) ExchangeSnapshot() []ExchangeMiddleware {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]ExchangeMiddleware{}, m.exchangeMiddleware...)
+}
+
 // Request middleware management
 func (m *Middleware) AddRequestMiddleware(middleware RequestMiddleware) {
 	m.mu.Lock()
@@ -122,12 +130,38 @@ func (m *Middleware) LenResponseMiddleware() int {
 	return len(m.responseMiddleware)
 }
 
+// Exchange middleware management
+func (m *Middleware) AddExchangeMiddleware(middleware ExchangeMiddleware) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exchangeMiddleware = append(m.exchangeMiddleware, middleware)
+}
+
+func (m *Middleware) SetExchangeMiddleware(middleware []ExchangeMiddleware) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exchangeMiddleware = append([]ExchangeMiddleware{}, middleware...)
+}
+
+func (m *Middleware) ClearExchangeMiddleware() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exchangeMiddleware = []ExchangeMiddleware{}
+}
+
+func (m *Middleware) LenExchangeMiddleware() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.exchangeMiddleware)
+}
+
 // Convenience methods for bulk operations
 func (m *Middleware) Clear() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.requestMiddleware = []RequestMiddleware{}
 	m.responseMiddleware = []ResponseMiddleware{}
+	m.exchangeMiddleware = []ExchangeMiddleware{}
 }
 
 func (m *Middleware) Len() (requestCount, responseCount int) {
@@ -15,9 +15,13 @@
 package verda
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -96,72 +100,45 @@ func TestShouldRetry(t *testing.T) {
 			err:      &APIError{StatusCode: 499, Message: "Custom Client Error"},
 			expected: false,
 		},
-		// Pattern matching for non-retryable errors
+		// Errors are classified by type, never by their text
 		{
-			name:     "authentication error",
-			err:      errors.New("authentication failed"),
+			name:     "message mentioning a timeout",
+			err:      errors.New("request timeout occurred"),
 			expected: false,
 		},
 		{
-			name:     "unauthorized error",
-			err:      errors.New("unauthorized access"),
-			expected: false,
+			name:     "connection refused",
+			err:      &url.Error{Op: "Get", URL: "https://api.verda.com/v1/balance", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}},
+			expected: true,
 		},
 		{
-			name:     "forbidden error",
-			err:      errors.New("forbidden resource"),
-			expected: false,
+			name:     "connection closed by the server",
+			err:      &url.Error{Op: "Get", URL: "https://api.verda.com/v1/balance", Err: io.EOF},
+			expected: true,
 		},
 		{
-			name:     "not found error",
-			err:      errors.New("resource not found"),
+			name:     "invalid URL",
+			err:      &url.Error{Op: "Get", URL: "ftp://api", Err: errors.New("unsupported protocol scheme")},
 			expected: false,
 		},
 		{
-			name:     "invalid request error",
-			err:      errors.New("invalid request parameters"),
+			name:     "context canceled",
+			err:      fmt.Errorf("request failed: %w", context.Canceled),
 			expected: false,
 		},
 		{
-			name:     "bad request error",
-			err:      errors.New("bad request format"),
+			name:     "deadline exceeded",
+			err:      &url.Error{Op: "Get", URL: "https://api.verda.com/v1/balance", Err: context.DeadlineExceeded},
 			expected: false,
 		},
-		// Pattern matching for retryable errors
-		{
-			name:     "timeout error",
-			err:      errors.New("request timeout occurred"),
-			expected: true,
-		},
-		{
-			name:     "connection error",
-			err:      errors.New("connection refused"),
-			expected: true,
-		},
 		{
-			name:     "temporary error",
-			err:      errors.New("temporary network failure"),
-			expected: true,
-		},
-		{
-			name:     "rate limit error",
-			err:      errors.New("rate limit exceeded"),
-			expected: true,
-		},
-		{
-			name:     "too many requests error",
-			err:      errors.New("too many requests sent"),
-			expected: true,
-		},
-		// Case insensitive matching
-		{
-			name:     "TIMEOUT uppercase",
-			err:      errors.New("REQUEST TIMEOUT"),
-			expected: true,
+			name:     "circuit open",
+			err:      ErrCircuitOpen,
+			expected: false,
 		},
 		{
-			name:     "Authentication uppercase",
-			err:      errors.New("AUTHENTICATION FAILED"),
+			name:     "409 Conflict",
+			err:      &APIError{StatusCode: 409, Message: "Rate limit exceeded"},
 			expected: false,
 		},
 		// Unknown errors default to non-retryable
@@ -0,0 +1,78 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oauth2verda converts between verda.TokenSource and
+// golang.org/x/oauth2.TokenSource.
+//
+// Any oauth2 token source can authenticate a client, for example the
+// standard client credentials flow against the Verda token endpoint:
+//
+//	config := clientcredentials.Config{
+//	    ClientID:     id,
+//	    ClientSecret: secret,
+//	    TokenURL:     verda.DefaultBaseURL + "/oauth2/token",
+//	}
+//	client, _ := verda.NewClient(
+//	    verda.WithTokenSource(oauth2verda.FromOAuth2(config.TokenSource(ctx))),
+//	)
+package oauth2verda
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// FromOAuth2 returns a verda.TokenSource backed by source. The oauth2 source
+// does not take a context, so a cancelled call still waits for it.
+func FromOAuth2(source oauth2.TokenSource) verda.TokenSource {
+	return verda.TokenSourceFunc(func(context.Context) (*verda.Token, error) {
+		token, err := source.Token()
+		if err != nil {
+			return nil, err
+		}
+		return &verda.Token{
+			AccessToken:  token.AccessToken,
+			TokenType:    token.TokenType,
+			RefreshToken: token.RefreshToken,
+			Expiry:       token.Expiry,
+		}, nil
+	})
+}
+
+// ToOAuth2 returns an oauth2.TokenSource backed by source, which is called
+// with ctx. Use it to hand the SDK's credentials to other oauth2 clients.
+func ToOAuth2(ctx context.Context, source verda.TokenSource) oauth2.TokenSource {
+	return tokenSource{ctx: ctx, source: source}
+}
+
+type tokenSource struct {
+	ctx    context.Context //nolint:containedctx // oauth2.TokenSource takes no context
+	source verda.TokenSource
+}
+
+func (s tokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.source.Token(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{
+		AccessToken:  token.AccessToken,
+		TokenType:    token.Type(),
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.Expiry,
+	}, nil
+}
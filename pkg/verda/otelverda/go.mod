@@ -0,0 +1,26 @@
+module github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/otelverda
+
+go 1.25.0
+
+require (
+	github.com/verda-cloud/verdacloud-sdk-go v1.5.0
+	go.opentelemetry.io/otel v1.46.0
+	go.opentelemetry.io/otel/sdk v1.46.0
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/go-logr/logr v1.4.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/go-ozzo/ozzo-validation/v4 v4.3.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	go.opentelemetry.io/otel/metric v1.46.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
+
+// Builds against this checkout of the SDK during development. Users of this
+// module get the SDK version required above; make release sets it to the
+// release being tagged.
+replace github.com/verda-cloud/verdacloud-sdk-go => ../../..
@@ -0,0 +1,142 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelverda adapts OpenTelemetry tracing to the verda.Tracer interface.
+//
+//	client, _ := verda.NewClient(
+//	    verda.WithClientID(id),
+//	    verda.WithClientSecret(secret),
+//	    verda.WithTracer(otelverda.NewTracer()),
+//	)
+package otelverda
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// InstrumentationName identifies the spans created by this package
+const InstrumentationName = "github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/otelverda"
+
+// Option configures a Tracer
+type Option func(*Tracer)
+
+// WithTracerProvider uses provider instead of the global tracer provider
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(t *Tracer) {
+		t.provider = provider
+	}
+}
+
+// WithPropagator uses propagator instead of the global text map propagator
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(t *Tracer) {
+		t.propagator = propagator
+	}
+}
+
+// Tracer implements verda.Tracer on top of OpenTelemetry
+type Tracer struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+	tracer     trace.Tracer
+}
+
+var _ verda.Tracer = (*Tracer)(nil)
+
+// NewTracer creates a Tracer using the global OpenTelemetry provider and
+// propagator unless options override them
+func NewTracer(options ...Option) *Tracer {
+	t := &Tracer{}
+	for _, option := range options {
+		option(t)
+	}
+	if t.provider == nil {
+		t.provider = otel.GetTracerProvider()
+	}
+	if t.propagator == nil {
+		t.propagator = otel.GetTextMapPropagator()
+	}
+	t.tracer = t.provider.Tracer(InstrumentationName, trace.WithInstrumentationVersion(verda.SDKVersion()))
+	return t
+}
+
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...verda.Attribute) (context.Context, verda.Span) {
+	ctx, span := t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(convert(attrs)...),
+	)
+	return ctx, &otelSpan{span: span}
+}
+
+func (t *Tracer) Inject(ctx context.Context, header http.Header) {
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// otelSpan wraps an OpenTelemetry span
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s *otelSpan) SetAttributes(attrs ...verda.Attribute) {
+	s.span.SetAttributes(convert(attrs)...)
+}
+
+func (s *otelSpan) AddEvent(name string, attrs ...verda.Attribute) {
+	s.span.AddEvent(name, trace.WithAttributes(convert(attrs)...))
+}
+
+func (s *otelSpan) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *otelSpan) End() {
+	s.span.End()
+}
+
+// convert maps verda attributes to OpenTelemetry ones; durations are
+// recorded in milliseconds
+func convert(attrs []verda.Attribute) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			out = append(out, attribute.String(a.Key, v))
+		case bool:
+			out = append(out, attribute.Bool(a.Key, v))
+		case int:
+			out = append(out, attribute.Int(a.Key, v))
+		case int64:
+			out = append(out, attribute.Int64(a.Key, v))
+		case float64:
+			out = append(out, attribute.Float64(a.Key, v))
+		case time.Duration:
+			out = append(out, attribute.Float64(a.Key+"_ms", float64(v)/float64(time.Millisecond)))
+		default:
+			out = append(out, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+	return out
+}
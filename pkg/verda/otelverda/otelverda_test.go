@@ -0,0 +1,184 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelverda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+func newTLSServer(t *testing.T, traceparent *string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("GET /balance", func(w http.ResponseWriter, r *http.Request) {
+		*traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"amount":10,"currency":"eur"}`))
+	})
+	mux.HandleFunc("GET /instances/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"not_found","message":"instance not found"}`))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func attrMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, a := range attrs {
+		m[a.Key] = a.Value
+	}
+	return m
+}
+
+func TestTracer(t *testing.T) {
+	var traceparent string
+	server := newTLSServer(t, &traceparent)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := NewTracer(WithTracerProvider(provider), WithPropagator(propagation.TraceContext{}))
+
+	client, err := verda.NewClient(
+		verda.WithBaseURL(server.URL),
+		verda.WithClientID("id"),
+		verda.WithClientSecret("secret"),
+		verda.WithHTTPClient(server.Client()),
+		verda.WithTracer(tracer),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "provision")
+	if _, err := client.Balance.Get(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Instances.GetByID(ctx, "inst_1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	balance, instance := spans[0], spans[1]
+
+	t.Run("span per call is a child of the caller's span", func(t *testing.T) {
+		if balance.Name != "GET /balance" || balance.SpanKind != trace.SpanKindClient {
+			t.Errorf("unexpected span %q (%v)", balance.Name, balance.SpanKind)
+		}
+		if balance.Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Error("expected the span to be a child of the caller's span")
+		}
+	})
+
+	t.Run("trace context is propagated", func(t *testing.T) {
+		want := balance.SpanContext.TraceID().String()
+		if traceparent == "" || traceparent[3:35] != want {
+			t.Errorf("expected traceparent for trace %s, got %q", want, traceparent)
+		}
+	})
+
+	t.Run("attributes and timings are recorded", func(t *testing.T) {
+		attrs := attrMap(balance.Attributes)
+		if attrs[verda.AttrHTTPMethod].AsString() != http.MethodGet ||
+			attrs[verda.AttrURLTemplate].AsString() != "/balance" ||
+			attrs[verda.AttrHTTPStatusCode].AsInt64() != http.StatusOK {
+			t.Errorf("unexpected attributes: %v", balance.Attributes)
+		}
+
+		if len(balance.Events) != 1 || balance.Events[0].Name != verda.EventHTTPAttempt {
+			t.Fatalf("expected one attempt event, got %+v", balance.Events)
+		}
+		timings := attrMap(balance.Events[0].Attributes)
+		if _, ok := timings[verda.AttrFirstByte+"_ms"]; !ok {
+			t.Errorf("expected a first byte timing, got %v", balance.Events[0].Attributes)
+		}
+	})
+
+	t.Run("errors carry the API error code", func(t *testing.T) {
+		attrs := attrMap(instance.Attributes)
+		if instance.Name != "GET /instances/{id}" || attrs[verda.AttrAPIErrorCode].AsString() != "not_found" {
+			t.Errorf("unexpected span %q with %v", instance.Name, instance.Attributes)
+		}
+		if instance.Status.Code != codes.Error {
+			t.Errorf("expected an error status, got %v", instance.Status)
+		}
+	})
+}
+
+func TestTracer_TLSTimings(t *testing.T) {
+	var traceparent string
+	server := newTLSServer(t, &traceparent)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client, err := verda.NewClient(
+		verda.WithBaseURL(server.URL),
+		verda.WithClientID("id"),
+		verda.WithClientSecret("secret"),
+		verda.WithHTTPClient(server.Client()),
+		verda.WithTracer(NewTracer(WithTracerProvider(provider))),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Authenticate first, then switch to a fresh transport so the traced
+	// request dials its own connection
+	if _, err := client.Auth.GetBearerTokenContext(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.HTTPClient = &http.Client{Transport: server.Client().Transport.(*http.Transport).Clone()}
+
+	if _, err := client.Balance.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || len(spans[0].Events) != 1 {
+		t.Fatalf("expected one span with one attempt, got %+v", spans)
+	}
+	timings := attrMap(spans[0].Events[0].Attributes)
+	for _, key := range []string{verda.AttrConnectTime, verda.AttrTLSDuration, verda.AttrFirstByte} {
+		if v, ok := timings[attribute.Key(key+"_ms")]; !ok || v.AsFloat64() <= 0 {
+			t.Errorf("expected %s to be recorded, got %v", key, spans[0].Events[0].Attributes)
+		}
+	}
+	if timings[verda.AttrConnReused].AsBool() {
+		t.Error("expected a fresh connection")
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit describes a client-side limit on outgoing requests. Zero fields
+// are unlimited.
+type RateLimit struct {
+	// RequestsPerSecond is the steady-state rate of the token bucket
+	RequestsPerSecond float64
+	// Burst is the bucket size, i.e. how many requests may be sent back to back.
+	// Defaults to RequestsPerSecond rounded up, with a minimum of 1.
+	Burst int
+	// MaxConcurrent caps the number of requests in flight at once
+	MaxConcurrent int
+}
+
+// WithRateLimit limits every request the client sends. Requests wait for a
+// token (or a free concurrency slot) and give up if their context is cancelled.
+func WithRateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) {
+		c.rateLimiterFor().global = newEndpointLimiter(limit)
+	}
+}
+
+// WithEndpointRateLimit adds a separate limit for requests whose path starts
+// with pathPrefix, e.g. "/instances" or "/volumes". It applies on top of the
+// global WithRateLimit limit. When several prefixes match, the longest wins.
+func WithEndpointRateLimit(pathPrefix string, limit RateLimit) ClientOption {
+	return func(c *Client) {
+		c.rateLimiterFor().setEndpoint(pathPrefix, newEndpointLimiter(limit))
+	}
+}
+
+// rateLimiterFor returns the client's rate limiter, creating it on first use
+func (c *Client) rateLimiterFor() *rateLimiter {
+	if c.rateLimiter == nil {
+		c.rateLimiter = &rateLimiter{}
+	}
+	return c.rateLimiter
+}
+
+// rateLimiter holds the global limit and any per-path-prefix limits
+type rateLimiter struct {
+	global    *endpointLimiter
+	endpoints []prefixLimiter // sorted longest prefix first
+}
+
+type prefixLimiter struct {
+	prefix  string
+	limiter *endpointLimiter
+}
+
+func (r *rateLimiter) setEndpoint(prefix string, limiter *endpointLimiter) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	for i := range r.endpoints {
+		if r.endpoints[i].prefix == prefix {
+			r.endpoints[i].limiter = limiter
+			return
+		}
+	}
+	r.endpoints = append(r.endpoints, prefixLimiter{prefix: prefix, limiter: limiter})
+	sort.SliceStable(r.endpoints, func(i, j int) bool {
+		return len(r.endpoints[i].prefix) > len(r.endpoints[j].prefix)
+	})
+}
+
+// endpointFor returns the limiter of the longest prefix matching path on a
+// segment boundary, so "/instances" does not match "/instance-types"
+func (r *rateLimiter) endpointFor(path string) *endpointLimiter {
+	for _, e := range r.endpoints {
+		if path == e.prefix || strings.HasPrefix(path, e.prefix+"/") {
+			return e.limiter
+		}
+	}
+	return nil
+}
+
+// acquire blocks until path may be requested. The returned release func must
+// be called once the response has been consumed.
+func (r *rateLimiter) acquire(ctx context.Context, path string) (func(), error) {
+	var releases []func()
+	releaseAll := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	for _, l := range []*endpointLimiter{r.endpointFor(path), r.global} {
+		if l == nil {
+			continue
+		}
+		release, err := l.acquire(ctx)
+		if err != nil {
+			releaseAll()
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+
+	return releaseAll, nil
+}
+
+// endpointLimiter combines a token bucket with a concurrency semaphore
+type endpointLimiter struct {
+	bucket *tokenBucket
+	slots  chan struct{}
+}
+
+func newEndpointLimiter(limit RateLimit) *endpointLimiter {
+	l := &endpointLimiter{}
+	if limit.RequestsPerSecond > 0 {
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = max(int(math.Ceil(limit.RequestsPerSecond)), 1)
+		}
+		l.bucket = newTokenBucket(limit.RequestsPerSecond, burst)
+	}
+	if limit.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, limit.MaxConcurrent)
+	}
+	return l
+}
+
+func (l *endpointLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for a free request slot: %w", ctx.Err())
+		}
+	}
+
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			release()
+			return nil, fmt.Errorf("waiting for rate limit: %w", err)
+		}
+	}
+
+	return release, nil
+}
+
+// tokenBucket is a minimal token bucket rate limiter
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long
+// to wait before one will be
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := b.reserve()
+		if delay == 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// releaseOnClose calls release once the wrapped body is closed, so a
+// concurrency slot stays taken until the response has been consumed
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
+// roundTrip sends a single HTTP request after checking the circuit breaker and
+// waiting for the client's rate limits. Every request the SDK makes to the API
+// goes through here.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	return c.limitedRoundTrip(req, c.observeRequest)
+}
+
+// limitedRoundTrip is roundTrip with the metrics hook passed in. Token
+// requests pass nil; they are counted by observeTokenRefresh instead.
+func (c *Client) limitedRoundTrip(req *http.Request, observe func(*http.Request, *http.Response, error, time.Time)) (*http.Response, error) {
+	if observe == nil {
+		observe = func(*http.Request, *http.Response, error, time.Time) {}
+	}
+	path := c.relativePath(req.URL.Path)
+
+	cb := c.CircuitBreaker
+	group := endpointGroup(path)
+	if cb != nil {
+		if err := cb.Allow(group); err != nil {
+			return nil, err
+		}
+	}
+
+	release := func() {}
+	if c.rateLimiter != nil {
+		var err error
+		release, err = c.rateLimiter.acquire(req.Context(), path)
+		if err != nil {
+			if cb != nil {
+				cb.release(group)
+			}
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.HTTPClient.Do(req) //nolint:gosec // G704: SDK client - URL is configured by caller
+	if cb != nil {
+		if err != nil && req.Context().Err() != nil {
+			cb.release(group)
+		} else {
+			cb.Record(group, !isCircuitFailure(resp, err))
+		}
+	}
+	if err != nil {
+		observe(req, nil, err, start)
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	observe(req, resp, nil, start)
+	return resp, nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func newRateLimitedTestClient(mockServer *testutil.MockServer, options ...ClientOption) *Client {
+	config := testutil.NewTestClientConfig(mockServer)
+	client, _ := NewClient(append([]ClientOption{
+		WithBaseURL(config.BaseURL),
+		WithClientID(config.ClientID),
+		WithClientSecret(config.ClientSecret),
+	}, options...)...)
+	return client
+}
+
+func TestTokenBucket(t *testing.T) {
+	t.Run("burst is available immediately", func(t *testing.T) {
+		b := newTokenBucket(1, 3)
+		for i := 0; i < 3; i++ {
+			if d := b.reserve(); d != 0 {
+				t.Fatalf("expected token %d to be available, wait %v", i+1, d)
+			}
+		}
+		if d := b.reserve(); d <= 0 {
+			t.Error("expected to wait once the burst is spent")
+		}
+	})
+
+	t.Run("wait paces requests", func(t *testing.T) {
+		b := newTokenBucket(100, 1)
+		start := time.Now()
+		for i := 0; i < 6; i++ {
+			if err := b.wait(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		// One token up front, then five more at 10ms each
+		if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+			t.Errorf("expected ~50ms of pacing, took %v", elapsed)
+		}
+	})
+
+	t.Run("wait honours cancellation", func(t *testing.T) {
+		b := newTokenBucket(0.001, 1)
+		b.reserve()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := b.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+func TestRateLimiter_EndpointFor(t *testing.T) {
+	r := &rateLimiter{}
+	instances := newEndpointLimiter(RateLimit{RequestsPerSecond: 1})
+	instanceTypes := newEndpointLimiter(RateLimit{RequestsPerSecond: 2})
+	tags := newEndpointLimiter(RateLimit{RequestsPerSecond: 3})
+	r.setEndpoint("/instances", instances)
+	r.setEndpoint("instance-types/", instanceTypes)
+	r.setEndpoint("/instances/abc/tags", tags)
+
+	tests := []struct {
+		path string
+		want *endpointLimiter
+	}{
+		{"/instances", instances},
+		{"/instances/abc", instances},
+		{"/instance-types", instanceTypes},
+		{"/instances/abc/tags", tags},
+		{"/instances/abc/tags/env", tags},
+		{"/volumes", nil},
+	}
+	for _, tt := range tests {
+		if got := r.endpointFor(tt.path); got != tt.want {
+			t.Errorf("endpointFor(%q) picked the wrong limiter", tt.path)
+		}
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	t.Run("global limit paces requests", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		client := newRateLimitedTestClient(mockServer, WithRateLimit(RateLimit{RequestsPerSecond: 50, Burst: 1}))
+		ctx := context.Background()
+
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			if _, err := client.Balance.Get(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+			t.Errorf("expected ~80ms of pacing, took %v", elapsed)
+		}
+	})
+
+	t.Run("endpoint limit only applies to its prefix", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		client := newRateLimitedTestClient(mockServer, WithEndpointRateLimit("/instances", RateLimit{RequestsPerSecond: 0.001, Burst: 1}))
+		ctx := context.Background()
+
+		if _, err := client.Instances.GetByID(ctx, "inst_1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Other endpoints are unaffected
+		for i := 0; i < 3; i++ {
+			if _, err := client.Balance.Get(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		// The instance bucket is empty and the wait is bounded by the context
+		limited, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if _, err := client.Instances.GetByID(limited, "inst_1"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("plain-text endpoints are limited too", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		client := newRateLimitedTestClient(mockServer, WithEndpointRateLimit("/instance-availability", RateLimit{RequestsPerSecond: 0.001, Burst: 1}))
+		ctx := context.Background()
+
+		if _, err := client.Instances.CheckInstanceTypeAvailability(ctx, "1V100.6V"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		limited, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if _, err := client.Instances.CheckInstanceTypeAvailability(limited, "1V100.6V"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("max concurrent caps in-flight requests", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var inFlight, peak int32
+		mockServer.SetHandler(http.MethodGet, "/slow", func(w http.ResponseWriter, _ *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			_, _ = w.Write([]byte(`{}`))
+		})
+
+		client := newRateLimitedTestClient(mockServer, WithRateLimit(RateLimit{MaxConcurrent: 2}))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, _, err := getRequest[map[string]any](context.Background(), client, "/slow"); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if peak > 2 {
+			t.Errorf("expected at most 2 requests in flight, saw %d", peak)
+		}
+	})
+}
+
+func TestClient_RelativePath(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		path    string
+		want    string
+	}{
+		{"https://api.verda.com/v1", "/v1/instances", "/instances"},
+		{"https://api.verda.com/v1/", "/v1/instances/abc", "/instances/abc"},
+		{"https://api.verda.com/v1", "/v1", "/"},
+		{"https://api.verda.com/v1", "/v10/instances", "/v10/instances"},
+		{"http://127.0.0.1:1234", "/instances", "/instances"},
+	}
+	for _, tt := range tests {
+		c := &Client{BaseURL: tt.baseURL}
+		if got := c.relativePath(tt.path); got != tt.want {
+			t.Errorf("relativePath(%q) with base %q = %q, expected %q", tt.path, tt.baseURL, got, tt.want)
+		}
+	}
+}
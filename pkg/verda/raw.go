@@ -0,0 +1,137 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Get calls an endpoint the SDK does not wrap yet and decodes the response
+// into T. path is relative to the base URL, e.g. "/instances". The call goes
+// through the same pipeline as the service methods: authentication,
+// middleware, retries, error handling, caching, metrics and tracing.
+//
+//	type Quota struct{ Limit, Used int }
+//	quota, err := verda.Get[Quota](ctx, client, "/quotas/gpu",
+//		verda.QueryParams(verda.Query{}.Set("location_code", "FIN-01")))
+//
+// Use CaptureResponse to read the response metadata.
+func Get[T any](ctx context.Context, client *Client, path string, opts ...RequestOption) (T, error) {
+	result, _, err := getRequest[T](withCallOptions(ctx, opts), client, path)
+	return result, err
+}
+
+// Post sends body as JSON to an endpoint the SDK does not wrap yet; see Get
+func Post[T any](ctx context.Context, client *Client, path string, body any, opts ...RequestOption) (T, error) {
+	result, _, err := postRequest[T](withCallOptions(ctx, opts), client, path, body)
+	return result, err
+}
+
+// Put sends body as JSON to an endpoint the SDK does not wrap yet; see Get
+func Put[T any](ctx context.Context, client *Client, path string, body any, opts ...RequestOption) (T, error) {
+	result, _, err := putRequest[T](withCallOptions(ctx, opts), client, path, body)
+	return result, err
+}
+
+// Patch sends body as JSON to an endpoint the SDK does not wrap yet; see Get
+func Patch[T any](ctx context.Context, client *Client, path string, body any, opts ...RequestOption) (T, error) {
+	result, _, err := patchRequest[T](withCallOptions(ctx, opts), client, path, body)
+	return result, err
+}
+
+// Delete calls an endpoint the SDK does not wrap yet; body may be nil for
+// endpoints that take none. See Get.
+func Delete[T any](ctx context.Context, client *Client, path string, body any, opts ...RequestOption) (T, error) {
+	ctx = withCallOptions(ctx, opts)
+	if body == nil {
+		result, _, err := deleteRequest[T](ctx, client, path)
+		return result, err
+	}
+	result, _, err := requestWithBody[T](ctx, client, http.MethodDelete, path, body)
+	return result, err
+}
+
+func withCallOptions(ctx context.Context, opts []RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	return WithRequestOptions(ctx, opts...)
+}
+
+// Query builds query parameters. Methods format values the way the API
+// expects them and return the query so calls can be chained:
+//
+//	verda.Query{}.Set("status", "running").Add("id", "a").Add("id", "b")
+type Query url.Values
+
+// Set replaces the values of key. Strings, fmt.Stringers, booleans, numbers
+// and times (as RFC 3339) are formatted; slices set one value per element.
+func (q Query) Set(key string, value any) Query {
+	delete(q, key)
+	return q.Add(key, value)
+}
+
+// Add appends to the values of key, formatting value like Set
+func (q Query) Add(key string, value any) Query {
+	q[key] = append(q[key], formatQueryValue(value)...)
+	return q
+}
+
+// Encode returns the query in URL-encoded form, sorted by key
+func (q Query) Encode() string {
+	return url.Values(q).Encode()
+}
+
+// QueryParams adds query parameters to each request, replacing parameters
+// of the same name already in its path
+func QueryParams(query Query) RequestOption {
+	return func(o *requestOptions) {
+		if o.query == nil {
+			o.query = make(url.Values)
+		}
+		for key, values := range query {
+			o.query[key] = append([]string(nil), values...)
+		}
+	}
+}
+
+func formatQueryValue(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case int:
+		return []string{strconv.Itoa(v)}
+	case int64:
+		return []string{strconv.FormatInt(v, 10)}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case time.Time:
+		return []string{v.UTC().Format(time.RFC3339)}
+	case fmt.Stringer:
+		return []string{v.String()}
+	default:
+		return []string{strings.TrimSpace(fmt.Sprint(v))}
+	}
+}
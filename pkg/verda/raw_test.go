@@ -0,0 +1,161 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+type testQuota struct {
+	Resource string `json:"resource"`
+	Limit    int    `json:"limit"`
+	Used     int    `json:"used"`
+}
+
+func TestRawRequests(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var lastRequest atomic.Pointer[http.Request]
+	var lastBody atomic.Value
+	var failures atomic.Int32
+	record := func(r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lastRequest.Store(r)
+		lastBody.Store(string(body))
+	}
+	writeQuota := func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"resource":"gpu","limit":8,"used":2}`))
+	}
+	mockServer.SetHandler(http.MethodGet, "/quotas/gpu", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		if failures.Add(-1) >= 0 {
+			testutil.ErrorResponse(w, http.StatusServiceUnavailable, "try again")
+			return
+		}
+		writeQuota(w)
+	})
+	mockServer.SetHandler(http.MethodPost, "/quotas/gpu", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		writeQuota(w)
+	})
+	mockServer.SetHandler(http.MethodDelete, "/quotas/gpu", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockServer.SetHandler(http.MethodGet, "/quotas/missing", func(w http.ResponseWriter, r *http.Request) {
+		testutil.ErrorResponse(w, http.StatusNotFound, "quota not found")
+	})
+
+	client := newRateLimitedTestClient(mockServer, WithRetryPolicy(&RetryPolicy{MaxRetries: 2, InitialDelay: time.Millisecond}))
+	ctx := context.Background()
+
+	t.Run("get decodes into T with query parameters", func(t *testing.T) {
+		failures.Store(1)
+		var meta ResponseMetadata
+		quota, err := Get[testQuota](CaptureResponse(ctx, &meta), client, "/quotas/gpu?scope=account",
+			QueryParams(Query{}.Set("location_code", "FIN-01").Add("include", []string{"used", "limit"})))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if quota != (testQuota{Resource: "gpu", Limit: 8, Used: 2}) {
+			t.Errorf("unexpected quota %+v", quota)
+		}
+
+		r := lastRequest.Load()
+		if got := r.URL.RawQuery; got != "include=used&include=limit&location_code=FIN-01&scope=account" {
+			t.Errorf("unexpected query %q", got)
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") || !strings.Contains(r.Header.Get("User-Agent"), "verdacloud-sdk-go") {
+			t.Errorf("expected the client headers, got %v", r.Header)
+		}
+		if meta.Attempts != 2 {
+			t.Errorf("expected the retry policy to apply, got %d attempts", meta.Attempts)
+		}
+	})
+
+	t.Run("post sends JSON", func(t *testing.T) {
+		quota, err := Post[testQuota](ctx, client, "/quotas/gpu", map[string]int{"limit": 8}, Header("X-Reason", "scale-up"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if quota.Limit != 8 {
+			t.Errorf("unexpected quota %+v", quota)
+		}
+
+		r := lastRequest.Load()
+		var body map[string]int
+		if err := json.Unmarshal([]byte(lastBody.Load().(string)), &body); err != nil || body["limit"] != 8 {
+			t.Errorf("unexpected body %v (%v)", lastBody.Load(), err)
+		}
+		if r.Header.Get("Content-Type") != "application/json" || r.Header.Get("X-Reason") != "scale-up" || r.Header.Get(IdempotencyKeyHeader) == "" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+	})
+
+	t.Run("delete with and without a body", func(t *testing.T) {
+		if _, err := Delete[struct{}](ctx, client, "/quotas/gpu", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if body := lastBody.Load().(string); body != "" {
+			t.Errorf("expected no body, got %q", body)
+		}
+
+		if _, err := Delete[struct{}](ctx, client, "/quotas/gpu", map[string]bool{"force": true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if body := lastBody.Load().(string); body != `{"force":true}` {
+			t.Errorf("unexpected body %q", body)
+		}
+	})
+
+	t.Run("errors are typed", func(t *testing.T) {
+		_, err := Get[testQuota](ctx, client, "/quotas/missing")
+		if !IsNotFound(err) {
+			t.Errorf("expected a not found error, got %v", err)
+		}
+	})
+}
+
+func TestQuery(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	q := Query{}.
+		Set("status", StatusRunning).
+		Set("is_spot", true).
+		Set("limit", 50).
+		Set("created_after", created).
+		Add("id", "a").
+		Add("id", "b")
+
+	want := "created_after=2026-03-01T11%3A00%3A00Z&id=a&id=b&is_spot=true&limit=50&status=running"
+	if got := q.Encode(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	q.Set("id", "c")
+	if got := q["id"]; len(got) != 1 || got[0] != "c" {
+		t.Errorf("expected Set to replace values, got %v", got)
+	}
+}
@@ -17,15 +17,97 @@ package verda
 import (
 	"bytes"
 	"context"
+	"encoding"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 type Response struct {
 	*http.Response
 }
 
+// decodeResponse decodes a successful response body into result. JSON is
+// tried first; some endpoints answer with a bare or quoted string instead,
+// which decodes into a *string, a *bool or an encoding.TextUnmarshaler.
+func decodeResponse(body []byte, result any) error {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || result == nil {
+		return nil
+	}
+
+	jsonErr := json.Unmarshal(trimmed, result)
+	if jsonErr == nil {
+		return nil
+	}
+
+	text := string(trimmed)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	switch r := result.(type) {
+	case *string:
+		*r = text
+		return nil
+	case *bool:
+		if b, err := strconv.ParseBool(text); err == nil {
+			*r = b
+			return nil
+		}
+	case encoding.TextUnmarshaler:
+		return r.UnmarshalText([]byte(text))
+	}
+	return fmt.Errorf("failed to unmarshal response: %w", jsonErr)
+}
+
+// createdResource is the response of create endpoints that return either the
+// new resource as JSON or only its ID, bare or quoted
+type createdResource[T any] struct {
+	Resource *T
+	ID       string
+}
+
+func (c *createdResource[T]) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &c.ID)
+	}
+	var resource T
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return err
+	}
+	c.Resource = &resource
+	return nil
+}
+
+func (c *createdResource[T]) UnmarshalText(text []byte) error {
+	c.ID = strings.TrimSpace(string(text))
+	return nil
+}
+
+// idList is a list of IDs that the API returns either as a JSON array or as a
+// single, possibly bare, string
+type idList []string
+
+func (l *idList) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var id string
+		if err := json.Unmarshal(data, &id); err != nil {
+			return err
+		}
+		*l = idList{id}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+func (l *idList) UnmarshalText(text []byte) error {
+	*l = idList{strings.TrimSpace(string(text))}
+	return nil
+}
+
 func getRequest[T any](ctx context.Context, client *Client, url string) (T, *Response, error) {
 	var respBody T
 
@@ -45,17 +127,7 @@ func getRequest[T any](ctx context.Context, client *Client, url string) (T, *Res
 func requestWithBody[T any](ctx context.Context, client *Client, method, url string, reqBody any) (T, *Response, error) {
 	var respBody T
 
-	var reqBodyReader io.Reader
-	if reqBody != nil {
-		reqBodyBytes, err := json.Marshal(reqBody)
-		if err != nil {
-			return respBody, nil, err
-		}
-
-		reqBodyReader = bytes.NewReader(reqBodyBytes)
-	}
-
-	req, err := client.NewRequest(ctx, method, url, reqBodyReader)
+	req, err := newJSONRequest(ctx, client, method, url, reqBody)
 	if err != nil {
 		return respBody, nil, err
 	}
@@ -68,12 +140,9 @@ func requestWithBody[T any](ctx context.Context, client *Client, method, url str
 	return respBody, resp, nil
 }
 
-func postRequest[T any](ctx context.Context, client *Client, url string, reqBody any) (T, *Response, error) {
-	return requestWithBody[T](ctx, client, http.MethodPost, url, reqBody)
-}
-
-//nolint:unused // Reserved for POST when response body is empty or not needed
-func postRequestAllowEmptyResponse(ctx context.Context, client *Client, url string, reqBody any) (*Response, error) {
+// newJSONRequest encodes reqBody as JSON and keeps the typed value on the
+// request's context for exchange middleware
+func newJSONRequest(ctx context.Context, client *Client, method, url string, reqBody any) (*http.Request, error) {
 	var reqBodyReader io.Reader
 	if reqBody != nil {
 		reqBodyBytes, err := json.Marshal(reqBody)
@@ -81,31 +150,19 @@ func postRequestAllowEmptyResponse(ctx context.Context, client *Client, url stri
 			return nil, err
 		}
 		reqBodyReader = bytes.NewReader(reqBodyBytes)
+		ctx = withRequestBody(ctx, reqBody)
 	}
 
-	req, err := client.NewRequest(ctx, http.MethodPost, url, reqBodyReader)
-	if err != nil {
-		return nil, err
-	}
-
-	return client.Do(req, nil)
+	return client.NewRequest(ctx, method, url, reqBodyReader)
 }
 
-func putRequest[T any](ctx context.Context, client *Client, url string, reqBody any) (T, *Response, error) {
-	return requestWithBody[T](ctx, client, http.MethodPut, url, reqBody)
+func postRequest[T any](ctx context.Context, client *Client, url string, reqBody any) (T, *Response, error) {
+	return requestWithBody[T](ctx, client, http.MethodPost, url, reqBody)
 }
 
-func putRequestAllowEmptyResponse(ctx context.Context, client *Client, url string, reqBody any) (*Response, error) {
-	var reqBodyReader io.Reader
-	if reqBody != nil {
-		reqBodyBytes, err := json.Marshal(reqBody)
-		if err != nil {
-			return nil, err
-		}
-		reqBodyReader = bytes.NewReader(reqBodyBytes)
-	}
-
-	req, err := client.NewRequest(ctx, http.MethodPut, url, reqBodyReader)
+//nolint:unused // Reserved for POST when response body is empty or not needed
+func postRequestAllowEmptyResponse(ctx context.Context, client *Client, url string, reqBody any) (*Response, error) {
+	req, err := newJSONRequest(ctx, client, http.MethodPost, url, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -113,11 +170,14 @@ func putRequestAllowEmptyResponse(ctx context.Context, client *Client, url strin
 	return client.Do(req, nil)
 }
 
+func putRequest[T any](ctx context.Context, client *Client, url string, reqBody any) (T, *Response, error) {
+	return requestWithBody[T](ctx, client, http.MethodPut, url, reqBody)
+}
+
 func patchRequest[T any](ctx context.Context, client *Client, url string, reqBody any) (T, *Response, error) {
 	return requestWithBody[T](ctx, client, http.MethodPatch, url, reqBody)
 }
 
-//nolint:unused // Reserved for DELETE endpoints with response bodies
 func deleteRequest[T any](ctx context.Context, client *Client, url string) (T, *Response, error) {
 	var respBody T
 
@@ -144,16 +204,7 @@ func deleteRequestAllowEmptyResponse(ctx context.Context, client *Client, url st
 }
 
 func deleteRequestWithBody(ctx context.Context, client *Client, url string, reqBody any) (*Response, error) {
-	var reqBodyReader io.Reader
-	if reqBody != nil {
-		reqBodyBytes, err := json.Marshal(reqBody)
-		if err != nil {
-			return nil, err
-		}
-		reqBodyReader = bytes.NewReader(reqBodyBytes)
-	}
-
-	req, err := client.NewRequest(ctx, http.MethodDelete, url, reqBodyReader)
+	req, err := newJSONRequest(ctx, client, http.MethodDelete, url, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,177 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ProjectIDParam is the query parameter that scopes a request to a project
+const ProjectIDParam = "projectId"
+
+// RequestOption overrides client behaviour for the calls made with a context
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	timeout    time.Duration
+	timeoutSet bool
+	header     http.Header
+	query      url.Values
+	retry      *RetryPolicy
+	retrySet   bool
+	noCache    bool
+	debug      bool
+	projectID  string
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context whose calls use opts on top of the
+// client settings and of options already in ctx:
+//
+//	ctx = verda.WithRequestOptions(ctx, verda.Timeout(5*time.Second), verda.NoRetry())
+//	instances, err := client.Instances.Get(ctx, "")
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	options := requestOptionsFrom(ctx).clone()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, options)
+}
+
+// Timeout bounds each call, including retries, instead of Client.Timeout. Zero
+// means no limit.
+func Timeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+		o.timeoutSet = true
+	}
+}
+
+// Header sets a header on each request. It takes precedence over the headers
+// the client sets itself, such as User-Agent, but not over Authorization.
+func Header(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// Retry replaces Client.RetryPolicy; nil disables retries
+func Retry(policy *RetryPolicy) RequestOption {
+	return func(o *requestOptions) {
+		o.retry = policy
+		o.retrySet = true
+	}
+}
+
+// NoRetry disables retries
+func NoRetry() RequestOption {
+	return Retry(nil)
+}
+
+// NoCache skips cached responses and stores fresh ones in their place, like
+// WithCacheRefresh
+func NoCache() RequestOption {
+	return func(o *requestOptions) {
+		o.noCache = true
+	}
+}
+
+// Debug logs the full requests and responses with the client's logger, or
+// with a standard debug logger when the client has none
+func Debug() RequestOption {
+	return func(o *requestOptions) {
+		o.debug = true
+	}
+}
+
+// Project scopes requests to a project by adding the projectId query
+// parameter, unless the request already has one
+func Project(projectID string) RequestOption {
+	return func(o *requestOptions) {
+		o.projectID = projectID
+	}
+}
+
+// requestOptionsFrom returns the options in ctx; never nil
+func requestOptionsFrom(ctx context.Context) *requestOptions {
+	if options, ok := ctx.Value(requestOptionsKey{}).(*requestOptions); ok {
+		return options
+	}
+	return &requestOptions{}
+}
+
+func (o *requestOptions) clone() *requestOptions {
+	clone := *o
+	clone.header = o.header.Clone()
+	if o.query != nil {
+		clone.query = make(url.Values, len(o.query))
+		for key, values := range o.query {
+			clone.query[key] = values
+		}
+	}
+	return &clone
+}
+
+// retryPolicy returns the retry policy for a call
+func (o *requestOptions) retryPolicy(client *RetryPolicy) *RetryPolicy {
+	if o.retrySet {
+		return o.retry
+	}
+	return client
+}
+
+// applyRequestOptions adds the per-call headers, query parameters and project
+// to req. It runs before the middleware so that they see the final request.
+func applyRequestOptions(req *http.Request, options *requestOptions) {
+	for key, values := range options.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if options.projectID == "" && len(options.query) == 0 {
+		return
+	}
+
+	query := req.URL.Query()
+	for key, values := range options.query {
+		query[key] = values
+	}
+	if options.projectID != "" && query.Get(ProjectIDParam) == "" {
+		query.Set(ProjectIDParam, options.projectID)
+	}
+	req.URL.RawQuery = query.Encode()
+}
+
+// callMiddleware snapshots the middleware for a call, adding the debug
+// middleware to a call made with Debug unless the client already logs every
+// call in detail
+func (c *Client) callMiddleware(ctx context.Context) ([]RequestMiddleware, []ResponseMiddleware) {
+	requestMiddleware, responseMiddleware := c.Middleware.Snapshot()
+	if !requestOptionsFrom(ctx).debug || c.debug {
+		return requestMiddleware, responseMiddleware
+	}
+
+	logger := c.Logger
+	if _, isNoOp := logger.(*NoOpLogger); isNoOp || logger == nil {
+		logger = NewStdLogger(true)
+	}
+	return append(requestMiddleware, DebugLoggingMiddleware(logger)),
+		append(responseMiddleware, DebugResponseLoggingMiddleware(logger))
+}
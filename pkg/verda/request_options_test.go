@@ -0,0 +1,166 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func TestRequestOptions(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var calls atomic.Int32
+	var lastRequest atomic.Pointer[http.Request]
+	status := http.StatusOK
+	mockServer.SetHandler(http.MethodGet, "/locations", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		lastRequest.Store(r)
+		if status != http.StatusOK {
+			testutil.ErrorResponse(w, status, "unavailable")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"code":"FIN-01","name":"Finland 1","country_code":"FI"}]`))
+	})
+	ctx := context.Background()
+
+	t.Run("headers override client headers", func(t *testing.T) {
+		client := newRateLimitedTestClient(mockServer, WithUserAgent("base"))
+		callCtx := WithRequestOptions(ctx, Header("X-Trace-Id", "abc"), Header("User-Agent", "one-off/1.0"))
+		if _, err := client.Locations.Get(callCtx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		r := lastRequest.Load()
+		if r.Header.Get("X-Trace-Id") != "abc" || r.Header.Get("User-Agent") != "one-off/1.0" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			t.Error("expected authorization to be kept")
+		}
+	})
+
+	t.Run("project scope", func(t *testing.T) {
+		client := newRateLimitedTestClient(mockServer)
+		if _, err := client.Locations.Get(WithRequestOptions(ctx, Project("proj-1"))); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := lastRequest.Load().URL.Query().Get(ProjectIDParam); got != "proj-1" {
+			t.Errorf("expected projectId proj-1, got %q", got)
+		}
+	})
+
+	t.Run("cache bypass", func(t *testing.T) {
+		client := newRateLimitedTestClient(mockServer, WithResponseCache(NewResponseCache(CacheConfig{})))
+		calls.Store(0)
+		for _, callCtx := range []context.Context{ctx, ctx, WithRequestOptions(ctx, NoCache())} {
+			if _, err := client.Locations.Get(callCtx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if n := calls.Load(); n != 2 {
+			t.Errorf("expected 2 server calls, got %d", n)
+		}
+	})
+
+	t.Run("retry overrides", func(t *testing.T) {
+		status = http.StatusServiceUnavailable
+		defer func() { status = http.StatusOK }()
+		policy := &RetryPolicy{MaxRetries: 2, InitialDelay: time.Millisecond}
+
+		tests := []struct {
+			name    string
+			options []ClientOption
+			call    []RequestOption
+			want    int32
+		}{
+			{"client policy", []ClientOption{WithRetryPolicy(policy)}, nil, 3},
+			{"no retry", []ClientOption{WithRetryPolicy(policy)}, []RequestOption{NoRetry()}, 1},
+			{"call policy", nil, []RequestOption{Retry(policy)}, 3},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				client := newRateLimitedTestClient(mockServer, tt.options...)
+				calls.Store(0)
+				if _, err := client.Locations.Get(WithRequestOptions(ctx, tt.call...)); err == nil {
+					t.Fatal("expected an error")
+				}
+				if n := calls.Load(); n != tt.want {
+					t.Errorf("expected %d attempts, got %d", tt.want, n)
+				}
+			})
+		}
+	})
+
+	t.Run("debug logs only the one call", func(t *testing.T) {
+		logger := newLineLogger()
+		client := newRateLimitedTestClient(mockServer, WithLogger(logger))
+		_, _ = client.Locations.Get(ctx)
+		_, _ = client.Locations.Get(WithRequestOptions(ctx, Debug()))
+
+		var requests int
+		for _, line := range logger.lines["info"] {
+			if strings.Contains(line, "API request") {
+				requests++
+			}
+		}
+		if requests != 1 {
+			t.Errorf("expected 1 logged request, got %d: %v", requests, logger.lines["info"])
+		}
+	})
+
+	t.Run("options accumulate", func(t *testing.T) {
+		outer := WithRequestOptions(ctx, Header("X-A", "1"), NoRetry())
+		inner := WithRequestOptions(outer, Header("X-B", "2"))
+		options := requestOptionsFrom(inner)
+		if options.header.Get("X-A") != "1" || options.header.Get("X-B") != "2" || !options.retrySet {
+			t.Errorf("unexpected options %+v", options)
+		}
+		if requestOptionsFrom(outer).header.Get("X-B") != "" {
+			t.Error("expected the outer context to be unchanged")
+		}
+	})
+}
+
+func TestRequestOptionsTimeout(t *testing.T) {
+	release := make(chan struct{})
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+	defer close(release)
+	mockServer.SetHandler(http.MethodGet, "/balance", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	client := newRateLimitedTestClient(mockServer)
+	start := time.Now()
+	_, err := client.Balance.Get(WithRequestOptions(context.Background(), Timeout(50*time.Millisecond)))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the call to stop after about 50ms, took %v", elapsed)
+	}
+}
@@ -19,7 +19,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
 )
 
 type TestUser struct {
@@ -46,6 +49,10 @@ func TestStandaloneRequestFunctions(t *testing.T) {
 				_ = json.NewEncoder(w).Encode(user)
 			}
 		case http.MethodPost:
+			if r.URL.Path == "/oauth2/token" {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token":"test_token","token_type":"Bearer","expires_in":3600}`))
+			}
 			if r.URL.Path == "/users" {
 				user := TestUser{ID: 3, Name: "New User"}
 				w.Header().Set("Content-Type", "application/json")
@@ -221,3 +228,100 @@ func TestServiceIntegration(t *testing.T) {
 		}
 	})
 }
+
+func TestDecodeResponse(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		for _, body := range []string{"vol-1", `"vol-1"`, " vol-1\n"} {
+			var id string
+			if err := decodeResponse([]byte(body), &id); err != nil || id != "vol-1" {
+				t.Errorf("decoding %q: got %q, %v", body, id, err)
+			}
+		}
+	})
+
+	t.Run("booleans", func(t *testing.T) {
+		for body, want := range map[string]bool{"true": true, `"true"`: true, `"false"`: false, "false": false} {
+			var got bool
+			if err := decodeResponse([]byte(body), &got); err != nil || got != want {
+				t.Errorf("decoding %q: got %v, %v", body, got, err)
+			}
+		}
+
+		var got bool
+		if err := decodeResponse([]byte(`"maybe"`), &got); err == nil {
+			t.Error("expected an error for a non-boolean body")
+		}
+	})
+
+	t.Run("created resources", func(t *testing.T) {
+		var created createdResource[SSHKey]
+		if err := decodeResponse([]byte(`{"id":"key-1","name":"laptop"}`), &created); err != nil || created.Resource == nil || created.Resource.Name != "laptop" {
+			t.Errorf("expected the resource, got %+v, %v", created, err)
+		}
+
+		for _, body := range []string{"key-1", `"key-1"`} {
+			created = createdResource[SSHKey]{}
+			if err := decodeResponse([]byte(body), &created); err != nil || created.Resource != nil || created.ID != "key-1" {
+				t.Errorf("decoding %q: expected the ID, got %+v, %v", body, created, err)
+			}
+		}
+	})
+
+	t.Run("ID lists", func(t *testing.T) {
+		for _, body := range []string{`["vol-1","vol-2"]`, `"vol-1"`, "vol-1"} {
+			var ids idList
+			if err := decodeResponse([]byte(body), &ids); err != nil || len(ids) == 0 || ids[0] != "vol-1" {
+				t.Errorf("decoding %q: got %v, %v", body, ids, err)
+			}
+		}
+	})
+
+	t.Run("JSON errors are reported", func(t *testing.T) {
+		var v struct{ Name string }
+		if err := decodeResponse([]byte("not json"), &v); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestPlainTextEndpointsUseMiddleware(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	mockServer.SetHandler(http.MethodPost, "/volumes", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("vol-new"))
+	})
+	mockServer.SetHandler(http.MethodGet, "/instance-availability/1V100.6V", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`"true"`))
+	})
+
+	client := NewTestClient(mockServer)
+	var seen []string
+	client.AddRequestMiddleware(func(next RequestHandler) RequestHandler {
+		return func(ctx *RequestContext) error {
+			seen = append(seen, ctx.Method+" "+ctx.Path)
+			return next(ctx)
+		}
+	})
+
+	ctx := context.Background()
+	volumeID, err := client.Volumes.CreateVolume(ctx, VolumeCreateRequest{Type: VolumeTypeNVMe, Name: "data", Size: 100})
+	if err != nil || volumeID != "vol-new" {
+		t.Fatalf("expected vol-new, got %q, %v", volumeID, err)
+	}
+	available, err := client.Instances.CheckInstanceTypeAvailability(ctx, "1V100.6V")
+	if err != nil || !available {
+		t.Fatalf("expected the type to be available, got %v, %v", available, err)
+	}
+	if _, err := client.SSHKeys.AddSSHKey(ctx, &CreateSSHKeyRequest{Name: "key", PublicKey: "ssh-ed25519 AAAA"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"POST /volumes", "GET /instance-availability/1V100.6V", "POST /ssh-keys"}
+	for _, w := range want {
+		if !slices.Contains(seen, w) {
+			t.Errorf("expected middleware to see %s, saw %v", w, seen)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ResponseMetadata describes the HTTP exchange behind a call
+type ResponseMetadata struct {
+	Method string
+	// Path is the API path, relative to the base URL
+	Path       string
+	StatusCode int
+	Header     http.Header
+	RequestID  string
+	// Attempts is the number of HTTP attempts, more than one after retries
+	Attempts int
+	// Cached reports whether the response came from the response cache
+	Cached bool
+	// Start is when the call began and Duration how long it took,
+	// including retries
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Date returns the server time from the Date header
+func (m *ResponseMetadata) Date() (time.Time, bool) {
+	date, err := http.ParseTime(m.Header.Get("Date"))
+	return date, err == nil
+}
+
+// ClockSkew estimates how far the server clock is ahead of the local clock.
+// The Date header has one second resolution, so neither is the estimate.
+func (m *ResponseMetadata) ClockSkew() (time.Duration, bool) {
+	date, ok := m.Date()
+	if !ok || m.Cached {
+		return 0, false
+	}
+	midpoint := m.Start.Add(m.Duration / 2)
+	return date.Sub(midpoint).Round(time.Second), true
+}
+
+// Deprecated reports whether the API flagged the endpoint as deprecated
+// with a Deprecation header
+func (m *ResponseMetadata) Deprecated() bool {
+	return m.Header.Get("Deprecation") != ""
+}
+
+// Sunset returns when a deprecated endpoint will be removed, from the Sunset
+// header
+func (m *ResponseMetadata) Sunset() (time.Time, bool) {
+	sunset, err := http.ParseTime(m.Header.Get("Sunset"))
+	return sunset, err == nil
+}
+
+type responseCaptureKey struct{}
+
+// responseCapture is where CaptureResponse stores metadata. Calls sharing the
+// context overwrite each other's metadata, so the last call wins.
+type responseCapture struct {
+	mu   sync.Mutex
+	meta *ResponseMetadata
+}
+
+// CaptureResponse returns a context that makes calls fill meta with the
+// metadata of their HTTP response, including error responses. Service methods
+// that send several requests leave the metadata of the last one.
+//
+//	var meta verda.ResponseMetadata
+//	instances, err := client.Instances.Get(verda.CaptureResponse(ctx, &meta), "")
+//	fmt.Println(meta.StatusCode, meta.RequestID, meta.Duration)
+func CaptureResponse(ctx context.Context, meta *ResponseMetadata) context.Context {
+	return context.WithValue(ctx, responseCaptureKey{}, &responseCapture{meta: meta})
+}
+
+type attemptCounterKey struct{}
+
+// startCapture prepares req for capturing its metadata and returns the
+// function that records it once the call is done
+func (c *Client) startCapture(req *http.Request) (*http.Request, func(*Response)) {
+	capture, ok := req.Context().Value(responseCaptureKey{}).(*responseCapture)
+	if !ok {
+		return req, func(*Response) {}
+	}
+
+	start := time.Now()
+	attempts := new(atomic.Int32)
+	req = req.WithContext(context.WithValue(req.Context(), attemptCounterKey{}, attempts))
+
+	return req, func(resp *Response) {
+		meta := ResponseMetadata{
+			Method:   req.Method,
+			Path:     c.relativePath(req.URL.Path),
+			Attempts: int(attempts.Load()),
+			Start:    start,
+			Duration: time.Since(start),
+		}
+		if resp != nil && resp.Response != nil {
+			meta.StatusCode = resp.StatusCode
+			meta.Header = resp.Header.Clone()
+			meta.RequestID = resp.Header.Get(RequestIDHeader)
+			meta.Cached = resp.Header.Get(CacheStatusHeader) == "hit"
+		}
+
+		capture.mu.Lock()
+		*capture.meta = meta
+		capture.mu.Unlock()
+	}
+}
+
+// countAttempt records an HTTP attempt of a call whose metadata is captured
+func countAttempt(ctx context.Context) {
+	if attempts, ok := ctx.Value(attemptCounterKey{}).(*atomic.Int32); ok {
+		attempts.Add(1)
+	}
+}
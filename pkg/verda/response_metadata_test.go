@@ -0,0 +1,112 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func TestCaptureResponse(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var failures atomic.Int32
+	serverTime := time.Now().Add(time.Hour).UTC()
+	mockServer.SetHandler(http.MethodGet, "/locations", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, "req-123")
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+		if failures.Add(-1) >= 0 {
+			testutil.ErrorResponse(w, http.StatusServiceUnavailable, "try again")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mockServer.SetHandler(http.MethodGet, "/instances/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, "req-404")
+		testutil.ErrorResponse(w, http.StatusNotFound, "instance not found")
+	})
+	ctx := context.Background()
+
+	t.Run("successful call", func(t *testing.T) {
+		client := newRateLimitedTestClient(mockServer)
+		var meta ResponseMetadata
+		if _, err := client.Locations.Get(CaptureResponse(ctx, &meta)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if meta.Method != http.MethodGet || meta.Path != "/locations" || meta.StatusCode != http.StatusOK ||
+			meta.RequestID != "req-123" || meta.Attempts != 1 || meta.Cached {
+			t.Errorf("unexpected metadata %+v", meta)
+		}
+		if meta.Start.IsZero() || meta.Duration <= 0 {
+			t.Errorf("expected timing, got start %v and duration %v", meta.Start, meta.Duration)
+		}
+		if skew, ok := meta.ClockSkew(); !ok || skew < 59*time.Minute || skew > 61*time.Minute {
+			t.Errorf("expected about an hour of clock skew, got %v", skew)
+		}
+		if sunset, ok := meta.Sunset(); !meta.Deprecated() || !ok || sunset.Year() != 2026 {
+			t.Errorf("expected deprecation and sunset, got %v, %v", meta.Deprecated(), sunset)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client := newRateLimitedTestClient(mockServer)
+		var meta ResponseMetadata
+		if _, err := client.Instances.GetByID(CaptureResponse(ctx, &meta), "missing"); !IsNotFound(err) {
+			t.Fatalf("expected not found, got %v", err)
+		}
+		if meta.StatusCode != http.StatusNotFound || meta.RequestID != "req-404" {
+			t.Errorf("unexpected metadata %+v", meta)
+		}
+	})
+
+	t.Run("retries are counted", func(t *testing.T) {
+		client := newRateLimitedTestClient(mockServer, WithRetryPolicy(&RetryPolicy{MaxRetries: 3, InitialDelay: time.Millisecond}))
+		failures.Store(2)
+		var meta ResponseMetadata
+		if _, err := client.Locations.Get(CaptureResponse(ctx, &meta)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if meta.Attempts != 3 || meta.StatusCode != http.StatusOK {
+			t.Errorf("expected 3 attempts ending in 200, got %+v", meta)
+		}
+	})
+
+	t.Run("cached responses", func(t *testing.T) {
+		failures.Store(0)
+		client := newRateLimitedTestClient(mockServer, WithResponseCache(NewResponseCache(CacheConfig{})))
+		_, _ = client.Locations.Get(ctx)
+
+		var meta ResponseMetadata
+		if _, err := client.Locations.Get(CaptureResponse(ctx, &meta)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !meta.Cached || meta.Attempts != 0 {
+			t.Errorf("expected a cache hit without attempts, got %+v", meta)
+		}
+		if _, ok := meta.ClockSkew(); ok {
+			t.Error("expected no clock skew estimate from a cached response")
+		}
+	})
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Retry defaults used when a RetryPolicy field is left at its zero value
+const (
+	DefaultRetryInitialDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay     = 30 * time.Second
+)
+
+// defaultRetryableStatusCodes are retried unless RetryPolicy.RetryableStatusCodes is set
+var defaultRetryableStatusCodes = []int{
+	http.StatusRequestTimeout,
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy controls how Client.Do replays failed HTTP calls. Each retry
+// re-sends the original request body; the wait between attempts grows
+// exponentially and is cut short if the request context is cancelled.
+//
+// Only idempotent methods (GET, HEAD, OPTIONS, TRACE, PUT, DELETE) are retried
+// unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// InitialDelay is the wait before the first retry. Defaults to 500ms.
+	InitialDelay time.Duration
+	// MaxDelay caps the exponential backoff. Defaults to 30s. A Retry-After
+	// header from the server is honoured even when it is longer.
+	MaxDelay time.Duration
+	// Jitter randomises each delay by ±Jitter (0.5 means ±50%) to avoid
+	// thundering herds. Zero disables jitter.
+	Jitter float64
+	// RetryNonIdempotent allows POST and PATCH requests to be retried
+	RetryNonIdempotent bool
+	// RetryableStatusCodes overrides the status codes that trigger a retry.
+	// Defaults to 408, 429, 500, 502, 503 and 504.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns a policy with 3 retries, 500ms initial delay,
+// a 30s cap and ±50% jitter
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:   3,
+		InitialDelay: DefaultRetryInitialDelay,
+		MaxDelay:     DefaultRetryMaxDelay,
+		Jitter:       0.5,
+	}
+}
+
+// WithRetryPolicy enables transport-level retries in Client.Do. Pass nil to
+// disable retries (the default).
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+// isIdempotentMethod reports whether replaying a request with method is safe
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// allows reports whether the policy permits retrying req at all
+func (p *RetryPolicy) allows(req *http.Request) bool {
+	return p.RetryNonIdempotent || isIdempotentMethod(req.Method)
+}
+
+// retryableStatus reports whether a response with statusCode should be retried
+func (p *RetryPolicy) retryableStatus(statusCode int) bool {
+	codes := p.RetryableStatusCodes
+	if codes == nil {
+		codes = defaultRetryableStatusCodes
+	}
+	return slices.Contains(codes, statusCode)
+}
+
+// backoff returns the delay before retry number attempt (1-based)
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial := p.InitialDelay
+	if initial <= 0 {
+		initial = DefaultRetryInitialDelay
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+
+	delay := math.Min(float64(initial)*math.Pow(2, float64(attempt-1)), float64(maxDelay))
+	if p.Jitter > 0 {
+		delay *= 1 + (cryptoRandFloat64()*2-1)*p.Jitter
+	}
+	return time.Duration(delay)
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send executes req, replaying it according to the client's RetryPolicy. The
+// response body is fully read and closed; its bytes are returned separately.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	ctx := req.Context()
+
+	// Buffer the body once so every attempt can send an identical copy
+	var payload []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		payload, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	policy := requestOptionsFrom(ctx).retryPolicy(c.RetryPolicy)
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.Clone(ctx)
+		if payload != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(payload))
+			attemptReq.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(payload)), nil
+			}
+			attemptReq.ContentLength = int64(len(payload))
+		}
+
+		countAttempt(ctx)
+		attemptReq, endAttempt := traceAttempt(attemptReq, attempt)
+		resp, body, err := c.sendOnce(attemptReq)
+		endAttempt(resp)
+
+		if policy == nil || attempt >= policy.MaxRetries || !policy.allows(req) || ctx.Err() != nil || errors.Is(err, ErrCircuitOpen) {
+			return resp, body, err
+		}
+		if err == nil && !policy.retryableStatus(resp.StatusCode) {
+			return resp, body, nil
+		}
+
+		delay, ok := retryAfter(resp)
+		if !ok {
+			delay = policy.backoff(attempt + 1)
+		}
+
+		attrs := []slog.Attr{
+			slog.String(LogKeyMethod, req.Method),
+			slog.String(LogKeyPath, req.URL.Path),
+			slog.Int(LogKeyAttempt, attempt+2),
+			slog.Int(LogKeyMaxAttempts, policy.MaxRetries+1),
+			slog.Duration(LogKeyDelay, delay),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.Any(LogKeyError, err))
+		} else {
+			attrs = append(attrs, slog.Int(LogKeyStatus, resp.StatusCode))
+			if id := resp.Header.Get(RequestIDHeader); id != "" {
+				attrs = append(attrs, slog.String(LogKeyRequestID, id))
+			}
+		}
+		c.structuredLogger().LogAttrs(ctx, slog.LevelDebug, "retrying request", attrs...)
+
+		c.observeRetry(req)
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			if err != nil {
+				return resp, body, err
+			}
+			return resp, body, fmt.Errorf("request failed: %w", sleepErr)
+		}
+	}
+}
+
+// sendOnce performs a single HTTP round trip and drains the response body
+func (c *Client) sendOnce(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return resp, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return resp, body, nil
+}
@@ -0,0 +1,282 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func newRetryTestClient(mockServer *testutil.MockServer, policy *RetryPolicy) *Client {
+	config := testutil.NewTestClientConfig(mockServer)
+	client, _ := NewClient(
+		WithBaseURL(config.BaseURL),
+		WithClientID(config.ClientID),
+		WithClientSecret(config.ClientSecret),
+		WithRetryPolicy(policy),
+	)
+	return client
+}
+
+// failingHandler returns status for the first failures calls, then 200 with body
+func failingHandler(calls *int32, failures int32, status int, header http.Header) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+		if n <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			testutil.ErrorResponse(w, status, http.StatusText(status))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}
+}
+
+func TestClientDo_RetryPolicy(t *testing.T) {
+	fastPolicy := &RetryPolicy{MaxRetries: 3, InitialDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	t.Run("retries GET until it succeeds", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/flaky", failingHandler(&calls, 2, http.StatusBadGateway, nil))
+		client := newRetryTestClient(mockServer, fastPolicy)
+
+		result, _, err := getRequest[map[string]bool](context.Background(), client, "/flaky")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result["ok"] {
+			t.Error("expected decoded response from the successful attempt")
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 attempts, got %d", calls)
+		}
+	})
+
+	t.Run("gives up after MaxRetries", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/down", failingHandler(&calls, 100, http.StatusServiceUnavailable, nil))
+		client := newRetryTestClient(mockServer, fastPolicy)
+
+		_, _, err := getRequest[map[string]bool](context.Background(), client, "/down")
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("expected 503 *APIError, got %v", err)
+		}
+		if calls != 4 {
+			t.Errorf("expected 4 attempts, got %d", calls)
+		}
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/missing", failingHandler(&calls, 100, http.StatusNotFound, nil))
+		client := newRetryTestClient(mockServer, fastPolicy)
+
+		if _, _, err := getRequest[map[string]bool](context.Background(), client, "/missing"); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 attempt, got %d", calls)
+		}
+	})
+
+	t.Run("does not retry POST by default", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodPost, "/create", failingHandler(&calls, 1, http.StatusBadGateway, nil))
+		client := newRetryTestClient(mockServer, fastPolicy)
+
+		if _, _, err := postRequest[map[string]bool](context.Background(), client, "/create", map[string]string{"a": "b"}); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 attempt, got %d", calls)
+		}
+	})
+
+	t.Run("replays the POST body when opted in", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		var bodies []string
+		mockServer.SetHandler(http.MethodPost, "/create", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			failingHandler(&calls, 2, http.StatusInternalServerError, nil)(w, r)
+		})
+
+		policy := *fastPolicy
+		policy.RetryNonIdempotent = true
+		client := newRetryTestClient(mockServer, &policy)
+
+		if _, _, err := postRequest[map[string]bool](context.Background(), client, "/create", map[string]string{"name": "box"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(bodies) != 3 {
+			t.Fatalf("expected 3 attempts, got %d", len(bodies))
+		}
+		for i, body := range bodies {
+			if body != `{"name":"box"}` {
+				t.Errorf("attempt %d sent body %q", i+1, body)
+			}
+		}
+	})
+
+	t.Run("honours Retry-After", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		header := http.Header{"Retry-After": []string{"1"}}
+		mockServer.SetHandler(http.MethodGet, "/throttled", failingHandler(&calls, 1, http.StatusTooManyRequests, header))
+		client := newRetryTestClient(mockServer, fastPolicy)
+
+		start := time.Now()
+		if _, _, err := getRequest[map[string]bool](context.Background(), client, "/throttled"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < time.Second {
+			t.Errorf("expected to wait for Retry-After (1s), waited %v", elapsed)
+		}
+	})
+
+	t.Run("cancellation stops retries", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/down", failingHandler(&calls, 100, http.StatusServiceUnavailable, nil))
+		client := newRetryTestClient(mockServer, &RetryPolicy{MaxRetries: 10, InitialDelay: time.Hour})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, _, err := getRequest[map[string]bool](ctx, client, "/down")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("expected retries to stop promptly, took %v", elapsed)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 attempt, got %d", calls)
+		}
+	})
+
+	t.Run("nil policy disables retries", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		mockServer.SetHandler(http.MethodGet, "/flaky", failingHandler(&calls, 1, http.StatusBadGateway, nil))
+		client := NewTestClient(mockServer)
+
+		if _, _, err := getRequest[map[string]bool](context.Background(), client, "/flaky"); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 attempt, got %d", calls)
+		}
+	})
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Errorf("backoff(%d) = %v, expected %v", i+1, got, want)
+		}
+	}
+
+	t.Run("jitter stays within bounds", func(t *testing.T) {
+		jittered := &RetryPolicy{InitialDelay: 100 * time.Millisecond, Jitter: 0.5}
+		for i := 0; i < 100; i++ {
+			if got := jittered.backoff(1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+				t.Fatalf("jittered delay %v out of bounds", got)
+			}
+		}
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		value  string
+		want   time.Duration
+		ok     bool
+	}{
+		{"seconds on 429", http.StatusTooManyRequests, "3", 3 * time.Second, true},
+		{"seconds on 503", http.StatusServiceUnavailable, "0", 0, true},
+		{"ignored on 500", http.StatusInternalServerError, "3", 0, false},
+		{"missing header", http.StatusTooManyRequests, "", 0, false},
+		{"garbage", http.StatusTooManyRequests, "soon", 0, false},
+		{"date in the past", http.StatusTooManyRequests, "Mon, 02 Jan 2006 15:04:05 GMT", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			if tt.value != "" {
+				resp.Header.Set("Retry-After", tt.value)
+			}
+			got, ok := retryAfter(resp)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("retryAfter() = (%v, %v), expected (%v, %v)", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestIsIdempotentMethod(t *testing.T) {
+	for method, want := range map[string]bool{
+		http.MethodGet:     true,
+		http.MethodPut:     true,
+		http.MethodDelete:  true,
+		http.MethodHead:    true,
+		http.MethodOptions: true,
+		http.MethodTrace:   true,
+		http.MethodPost:    false,
+		http.MethodPatch:   false,
+	} {
+		if got := isIdempotentMethod(method); got != want {
+			t.Errorf("isIdempotentMethod(%s) = %v, expected %v", method, got, want)
+		}
+	}
+}
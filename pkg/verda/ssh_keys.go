@@ -17,9 +17,7 @@ package verda
 import (
 	"context"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"iter"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -47,6 +45,19 @@ type DeleteMultipleSSHKeysRequest struct {
 	Keys []string `json:"keys"`
 }
 
+// SSHKeyListOptions filters SSHKeyService.List and All. Zero fields do not
+// filter.
+type SSHKeyListOptions struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	NamePrefix    string
+}
+
+func (o *SSHKeyListOptions) matches(k *SSHKey) bool {
+	return createdBetween(k.CreatedAt, o.CreatedAfter, o.CreatedBefore) &&
+		matchesPrefix(k.Name, o.NamePrefix)
+}
+
 // Validate validates the CreateSSHKeyRequest fields
 func (r CreateSSHKeyRequest) Validate() error {
 	return validation.ValidateStruct(&r,
@@ -55,6 +66,21 @@ func (r CreateSSHKeyRequest) Validate() error {
 	)
 }
 
+// List returns the SSH keys matching opts; nil opts lists all of them
+func (s *SSHKeyService) List(ctx context.Context, opts *SSHKeyListOptions) ([]SSHKey, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// All iterates over the SSH keys matching opts, which are fetched together
+// in one unpaginated request
+func (s *SSHKeyService) All(ctx context.Context, opts *SSHKeyListOptions) iter.Seq2[SSHKey, error] {
+	o := SSHKeyListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return listSeq(ctx, s.GetAllSSHKeys, o.matches)
+}
+
 func (s *SSHKeyService) GetAllSSHKeys(ctx context.Context) ([]SSHKey, error) {
 	keys, _, err := getRequest[[]SSHKey](ctx, s.client, "/ssh-keys")
 	if err != nil {
@@ -88,29 +114,14 @@ func (s *SSHKeyService) AddSSHKey(ctx context.Context, req *CreateSSHKeyRequest)
 }
 
 func (s *SSHKeyService) createWithPlainTextResponse(ctx context.Context, req *CreateSSHKeyRequest) (*SSHKey, error) {
-	resp, err := s.client.makeRequest(ctx, http.MethodPost, "/ssh-keys", req)
+	created, _, err := postRequest[createdResource[SSHKey]](ctx, s.client, "/ssh-keys", req)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read error response: %w", err)
-		}
-		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	if created.Resource != nil {
+		return created.Resource, nil
 	}
-
-	keyID := strings.TrimSpace(string(body))
-	return s.GetSSHKeyByID(ctx, keyID)
+	return s.GetSSHKeyByID(ctx, created.ID)
 }
 
 func (s *SSHKeyService) DeleteSSHKey(ctx context.Context, sshKeyID string) error {
@@ -16,11 +16,7 @@ package verda
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -89,41 +85,14 @@ func (s *StartupScriptService) AddStartupScript(ctx context.Context, req *Create
 
 // createWithPlainTextResponse handles API's inconsistent response format (sometimes JSON, sometimes plain text ID)
 func (s *StartupScriptService) createWithPlainTextResponse(ctx context.Context, req *CreateStartupScriptRequest) (*StartupScript, error) {
-	resp, err := s.client.makeRequest(ctx, http.MethodPost, "/scripts", req)
+	created, _, err := postRequest[createdResource[StartupScript]](ctx, s.client, "/scripts", req)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var apiError APIError
-		if err := json.Unmarshal(body, &apiError); err != nil {
-			return nil, &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    string(body),
-			}
-		}
-		apiError.StatusCode = resp.StatusCode
-		return nil, &apiError
+	if created.Resource != nil {
+		return created.Resource, nil
 	}
-
-	// Try JSON first, fall back to plain text ID
-	var script StartupScript
-	if err := json.Unmarshal(body, &script); err == nil {
-		return &script, nil
-	}
-
-	scriptID := strings.TrimSpace(string(body))
-	scriptID = strings.Trim(scriptID, "\"")
-
-	return s.GetStartupScriptByID(ctx, scriptID)
+	return s.GetStartupScriptByID(ctx, created.ID)
 }
 
 func (s *StartupScriptService) DeleteStartupScript(ctx context.Context, scriptID string) error {
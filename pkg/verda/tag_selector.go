@@ -0,0 +1,261 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ParseTagSelector parses a tag selector in the syntax of Kubernetes label
+// selectors. Requirements are separated by commas and must all hold:
+//
+//	team=ml              tag team has value ml; "==" works too
+//	env!=prod            tag env is missing or has another value
+//	env in (dev,qa)      tag env has one of the values
+//	env notin (prod)     tag env is missing or has none of the values
+//	gpu                  tag gpu is present, with any value or none (freeform)
+//	!gpu                 tag gpu is missing
+//
+// Keys are lowercased, as the API does. Values are trimmed and cannot contain
+// commas or parentheses. An empty selector matches every resource.
+func ParseTagSelector(selector string) (TagSelector, error) {
+	p := &selectorParser{input: selector}
+	query := tagQuery{}
+
+	p.skipSpace()
+	if p.done() {
+		return query, nil
+	}
+	for {
+		requirement, err := p.requirement()
+		if err != nil {
+			return nil, err
+		}
+		query = append(query, requirement)
+
+		p.skipSpace()
+		if p.done() {
+			return query, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ','")
+		}
+	}
+}
+
+type tagOperator int
+
+const (
+	tagExists tagOperator = iota
+	tagNotExists
+	tagEquals
+	tagNotEquals
+	tagIn
+	tagNotIn
+)
+
+type tagRequirement struct {
+	key    string
+	op     tagOperator
+	values []string
+}
+
+func (r tagRequirement) matches(tags []Tag) bool {
+	i := slices.IndexFunc(tags, func(tag Tag) bool { return tag.Key == r.key })
+	found := i >= 0
+	value := ""
+	if found {
+		value = tags[i].Value
+	}
+
+	switch r.op {
+	case tagExists:
+		return found
+	case tagNotExists:
+		return !found
+	case tagEquals:
+		return found && value == r.values[0]
+	case tagNotEquals:
+		return !found || value != r.values[0]
+	case tagIn:
+		return found && slices.Contains(r.values, value)
+	case tagNotIn:
+		return !found || !slices.Contains(r.values, value)
+	default:
+		return false
+	}
+}
+
+func (r tagRequirement) String() string {
+	switch r.op {
+	case tagNotExists:
+		return "!" + r.key
+	case tagEquals:
+		return r.key + "=" + r.values[0]
+	case tagNotEquals:
+		return r.key + "!=" + r.values[0]
+	case tagIn:
+		return r.key + " in (" + strings.Join(r.values, ",") + ")"
+	case tagNotIn:
+		return r.key + " notin (" + strings.Join(r.values, ",") + ")"
+	default:
+		return r.key
+	}
+}
+
+// tagQuery is a parsed selector; it matches when all its requirements do
+type tagQuery []tagRequirement
+
+func (q tagQuery) Matches(tags []Tag) bool {
+	for _, requirement := range q {
+		if !requirement.matches(tags) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in canonical form
+func (q tagQuery) String() string {
+	parts := make([]string, len(q))
+	for i, requirement := range q {
+		parts[i] = requirement.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+type selectorParser struct {
+	input string
+	pos   int
+}
+
+func (p *selectorParser) requirement() (tagRequirement, error) {
+	p.skipSpace()
+	if p.consume("!") {
+		key, err := p.key()
+		if err != nil {
+			return tagRequirement{}, err
+		}
+		return tagRequirement{key: key, op: tagNotExists}, nil
+	}
+
+	key, err := p.key()
+	if err != nil {
+		return tagRequirement{}, err
+	}
+
+	p.skipSpace()
+	switch {
+	case p.done() || p.peek(","):
+		return tagRequirement{key: key, op: tagExists}, nil
+	case p.consume("!="):
+		return tagRequirement{key: key, op: tagNotEquals, values: []string{p.value()}}, nil
+	case p.consume("=="), p.consume("="):
+		return tagRequirement{key: key, op: tagEquals, values: []string{p.value()}}, nil
+	}
+
+	op := tagIn
+	switch word := p.word(); word {
+	case "in":
+	case "notin":
+		op = tagNotIn
+	default:
+		return tagRequirement{}, p.errorf("expected an operator after key %q", key)
+	}
+	values, err := p.list()
+	if err != nil {
+		return tagRequirement{}, err
+	}
+	return tagRequirement{key: key, op: op, values: values}, nil
+}
+
+func (p *selectorParser) key() (string, error) {
+	p.skipSpace()
+	key := p.word()
+	if key == "" {
+		return "", p.errorf("expected a key")
+	}
+	if len(key) > TagKeyMaxLength {
+		return "", p.errorf("key %q is longer than %d characters", key, TagKeyMaxLength)
+	}
+	return strings.ToLower(key), nil
+}
+
+// value reads up to the next comma or parenthesis
+func (p *selectorParser) value() string {
+	start := p.pos
+	for !p.done() && !strings.ContainsRune(",()", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return strings.TrimSpace(p.input[start:p.pos])
+}
+
+func (p *selectorParser) list() ([]string, error) {
+	p.skipSpace()
+	if !p.consume("(") {
+		return nil, p.errorf("expected '('")
+	}
+	var values []string
+	for {
+		value := p.value()
+		if value == "" {
+			return nil, p.errorf("expected a value")
+		}
+		values = append(values, value)
+		if p.consume(")") {
+			return values, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' or ')'")
+		}
+	}
+}
+
+// word reads a run of characters that are neither spaces nor operators
+func (p *selectorParser) word() string {
+	start := p.pos
+	for !p.done() && !strings.ContainsRune(" \t\n,=!()", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *selectorParser) skipSpace() {
+	for !p.done() && strings.ContainsRune(" \t\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *selectorParser) consume(token string) bool {
+	if !p.peek(token) {
+		return false
+	}
+	p.pos += len(token)
+	return true
+}
+
+func (p *selectorParser) peek(token string) bool {
+	return strings.HasPrefix(p.input[p.pos:], token)
+}
+
+func (p *selectorParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid tag selector %q at offset %d: %s", p.input, p.pos, fmt.Sprintf(format, args...))
+}
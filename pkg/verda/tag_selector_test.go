@@ -0,0 +1,93 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseTagSelector(t *testing.T) {
+	tags := []Tag{
+		{Key: "team", Value: "ml"},
+		{Key: "env", Value: "dev"},
+		{Key: "gpu"},
+	}
+
+	tests := []struct {
+		selector string
+		matches  bool
+		canon    string
+	}{
+		{"", true, ""},
+		{"team=ml", true, "team=ml"},
+		{"team==ml", true, "team=ml"},
+		{"Team = ml", true, "team=ml"},
+		{"team=web", false, "team=web"},
+		{"env!=prod", true, "env!=prod"},
+		{"owner!=alice", true, "owner!=alice"},
+		{"env!=dev", false, "env!=dev"},
+		{"env in (dev, qa)", true, "env in (dev,qa)"},
+		{"env in (prod)", false, "env in (prod)"},
+		{"owner in (alice)", false, "owner in (alice)"},
+		{"env notin (prod,qa)", true, "env notin (prod,qa)"},
+		{"env notin (dev)", false, "env notin (dev)"},
+		{"owner notin (alice)", true, "owner notin (alice)"},
+		{"gpu", true, "gpu"},
+		{"gpu=", true, "gpu="},
+		{"team=", false, "team="},
+		{"!owner", true, "!owner"},
+		{"!gpu", false, "!gpu"},
+		{"team=ml, env in (dev,qa), gpu, !owner", true, "team=ml,env in (dev,qa),gpu,!owner"},
+		{"team=ml,env=prod", false, "team=ml,env=prod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := ParseTagSelector(tt.selector)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := selector.Matches(tags); got != tt.matches {
+				t.Errorf("expected Matches to return %v, got %v", tt.matches, got)
+			}
+			if got := fmt.Sprint(selector); got != tt.canon {
+				t.Errorf("expected %q, got %q", tt.canon, got)
+			}
+		})
+	}
+}
+
+func TestParseTagSelector_Errors(t *testing.T) {
+	for _, selector := range []string{
+		",",
+		"team=ml,",
+		"!",
+		"=ml",
+		"team ml",
+		"env in dev",
+		"env in ()",
+		"env in (dev",
+		"env in (dev,)",
+		"team=ml)",
+		"env notin (dev) x",
+	} {
+		t.Run(selector, func(t *testing.T) {
+			if _, err := ParseTagSelector(selector); err == nil {
+				t.Errorf("expected an error for %q", selector)
+			}
+		})
+	}
+}
@@ -16,10 +16,78 @@ package verda
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
+	"strings"
+	"sync"
 )
 
+// TagService searches instances, volumes and clusters by their tags
+type TagService struct {
+	client *Client
+}
+
+// Find returns the resources matching selector, in the syntax of
+// ParseTagSelector: instances first, then volumes, then clusters. kinds limits
+// the search to those kinds of resources; none searches all of them.
+//
+//	resources, err := client.Tags.Find(ctx, "team=ml,env!=prod", verda.ResourceKindInstance, verda.ResourceKindVolume)
+//	for _, r := range resources {
+//		fmt.Println(r.Kind, r.ID, r.Name)
+//	}
+func (s *TagService) Find(ctx context.Context, selector string, kinds ...ResourceKind) ([]TaggedResource, error) {
+	tags, err := ParseTagSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, kind := range kinds {
+		switch kind {
+		case ResourceKindInstance, ResourceKindVolume, ResourceKindCluster:
+		default:
+			return nil, fmt.Errorf("unknown resource kind %q", kind)
+		}
+	}
+	search := func(kind ResourceKind) bool {
+		return len(kinds) == 0 || slices.Contains(kinds, kind)
+	}
+
+	resources := []TaggedResource{}
+	if search(ResourceKindInstance) {
+		instances, err := s.client.Instances.List(ctx, &InstanceListOptions{Tags: tags})
+		if err != nil {
+			return nil, err
+		}
+		for i := range instances {
+			instance := &instances[i]
+			resources = append(resources, TaggedResource{Kind: ResourceKindInstance, ID: instance.ID, Name: instance.Hostname, Tags: instance.Tags, Instance: instance})
+		}
+	}
+	if search(ResourceKindVolume) {
+		volumes, err := s.client.Volumes.List(ctx, &VolumeListOptions{Tags: tags})
+		if err != nil {
+			return nil, err
+		}
+		for i := range volumes {
+			volume := &volumes[i]
+			resources = append(resources, TaggedResource{Kind: ResourceKindVolume, ID: volume.ID, Name: volume.Name, Tags: volume.Tags, Volume: volume})
+		}
+	}
+	if search(ResourceKindCluster) {
+		clusters, err := s.client.Clusters.List(ctx, &ClusterListOptions{Tags: tags})
+		if err != nil {
+			return nil, err
+		}
+		for i := range clusters {
+			cluster := &clusters[i]
+			resources = append(resources, TaggedResource{Kind: ResourceKindCluster, ID: cluster.ID, Name: cluster.Hostname, Tags: cluster.Tags, Cluster: cluster})
+		}
+	}
+	return resources, nil
+}
+
 // addResourceTag adds a single tag to the resource at basePath/resourceID.
 // Shared by the instance, volume and cluster tag endpoints, which are identical
 // apart from their base path.
@@ -59,3 +127,158 @@ func deleteResourceTag(ctx context.Context, client *Client, basePath, resourceID
 	_, err := deleteRequestAllowEmptyResponse(ctx, client, path)
 	return err
 }
+
+// SetTags makes each resource carry exactly the desired tags, updating
+// opts.Concurrency resources at a time; see InstanceService.SetTags. desired
+// is validated before any request is sent. The results are in the order of
+// resources, and the error joins those of the resources that failed.
+//
+//	resources, err := client.Tags.Find(ctx, "team=ml")
+//	refs := make([]verda.ResourceRef, len(resources))
+//	for i, r := range resources {
+//		refs[i] = r.Ref()
+//	}
+//	results, err := client.Tags.SetTags(ctx, refs, map[string]string{"team": "ml", "cost-center": "42"}, nil)
+func (s *TagService) SetTags(ctx context.Context, resources []ResourceRef, desired map[string]string, opts *BulkTagOptions) ([]SetTagsResult, error) {
+	if _, err := normalizeTags(desired); err != nil {
+		return nil, err
+	}
+	concurrency := DefaultBulkConcurrency
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	results := make([]SetTagsResult, len(resources))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, resource := range resources {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			// The resources not started yet are left untouched
+			for j := i; j < len(resources); j++ {
+				results[j] = SetTagsResult{Resource: resources[j], Changes: &TagChanges{}, Err: ctx.Err()}
+			}
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			changes, err := s.setTags(ctx, resource, desired)
+			results[i] = SetTagsResult{Resource: resource, Changes: changes, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", result.Resource.Kind, result.Resource.ID, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+func (s *TagService) setTags(ctx context.Context, resource ResourceRef, desired map[string]string) (*TagChanges, error) {
+	switch resource.Kind {
+	case ResourceKindInstance:
+		return s.client.Instances.SetTags(ctx, resource.ID, desired)
+	case ResourceKindVolume:
+		return s.client.Volumes.SetTags(ctx, resource.ID, desired)
+	case ResourceKindCluster:
+		return s.client.Clusters.SetTags(ctx, resource.ID, desired)
+	default:
+		return &TagChanges{}, fmt.Errorf("unknown resource kind %q", resource.Kind)
+	}
+}
+
+// normalizeTags validates desired tags against the API limits and lowercases
+// their keys, as the API does
+func normalizeTags(desired map[string]string) (map[string]string, error) {
+	if len(desired) > MaxTagsPerResource {
+		return nil, fmt.Errorf("%d tags requested, a resource can carry at most %d", len(desired), MaxTagsPerResource)
+	}
+	normalized := make(map[string]string, len(desired))
+	for key, value := range desired {
+		if err := (TagRequest{Key: key, Value: value}).Validate(); err != nil {
+			return nil, fmt.Errorf("tag %q: %w", key, err)
+		}
+		lower := strings.ToLower(key)
+		if _, ok := normalized[lower]; ok {
+			return nil, fmt.Errorf("tag %q is given more than once; keys are case-insensitive", lower)
+		}
+		normalized[lower] = value
+	}
+	return normalized, nil
+}
+
+// setResourceTags makes the resource at basePath/resourceID carry exactly the
+// desired tags. It deletes tags before adding any so that the resource stays
+// within MaxTagsPerResource, and stops at the first failed request, returning
+// the changes made until then. Each request gets its own idempotency key
+// derived from the one in ctx, if any.
+func setResourceTags(ctx context.Context, client *Client, basePath, resourceID string, desired map[string]string, current func(context.Context) ([]Tag, error)) (*TagChanges, error) {
+	changes := &TagChanges{}
+	if resourceID == "" {
+		return changes, fmt.Errorf("resource ID is required")
+	}
+	desired, err := normalizeTags(desired)
+	if err != nil {
+		return changes, err
+	}
+	tags, err := current(ctx)
+	if err != nil {
+		return changes, err
+	}
+
+	var deletes []Tag
+	var adds []TagRequest
+	existing := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		existing[tag.Key] = tag.Value
+		if value, ok := desired[tag.Key]; !ok || value != tag.Value {
+			deletes = append(deletes, tag)
+		}
+	}
+	for key, value := range desired {
+		if current, ok := existing[key]; !ok || current != value {
+			adds = append(adds, TagRequest{Key: key, Value: value})
+		}
+	}
+	// Sorted so that the requests are sent in a predictable order
+	slices.SortFunc(adds, func(a, b TagRequest) int { return strings.Compare(a.Key, b.Key) })
+
+	// replaced holds the deleted tags whose new value is not added yet
+	replaced := make(map[string]Tag)
+	for _, tag := range deletes {
+		keyCtx := withIdempotencyKeySuffix(ctx, resourceID+"-delete-"+url.QueryEscape(tag.Key))
+		if err := deleteResourceTag(keyCtx, client, basePath, resourceID, tag.Key); err != nil {
+			return changes, err
+		}
+		if _, ok := desired[tag.Key]; ok {
+			replaced[tag.Key] = tag
+		} else {
+			changes.Removed = append(changes.Removed, tag)
+		}
+	}
+	for _, req := range adds {
+		keyCtx := withIdempotencyKeySuffix(ctx, resourceID+"-add-"+url.QueryEscape(req.Key))
+		tag, err := addResourceTag(keyCtx, client, basePath, resourceID, req)
+		if err != nil {
+			for _, key := range slices.Sorted(maps.Keys(replaced)) {
+				changes.Removed = append(changes.Removed, replaced[key])
+			}
+			return changes, err
+		}
+		if _, ok := replaced[req.Key]; ok {
+			delete(replaced, req.Key)
+			changes.Updated = append(changes.Updated, *tag)
+		} else {
+			changes.Added = append(changes.Added, *tag)
+		}
+	}
+	return changes, nil
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"testing"
 
@@ -414,3 +415,303 @@ func TestCreateRequestsCarryTags(t *testing.T) {
 		}
 	})
 }
+
+func TestTagService_Find(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	ml := []Tag{{Key: "team", Value: "ml"}, {Key: "env", Value: "dev"}}
+	prod := []Tag{{Key: "team", Value: "ml"}, {Key: "env", Value: "prod"}}
+	mockServer.SetHandler(http.MethodGet, "/instances", jsonHandler(t, []Instance{
+		{ID: "i1", Hostname: "train", Tags: ml},
+		{ID: "i2", Hostname: "serve", Tags: prod},
+	}))
+	mockServer.SetHandler(http.MethodGet, "/volumes", jsonHandler(t, []Volume{
+		{ID: "v1", Name: "dataset", Tags: ml},
+		{ID: "v2", Name: "scratch"},
+	}))
+	mockServer.SetHandler(http.MethodGet, "/clusters", jsonHandler(t, []Cluster{
+		{ID: "c1", Hostname: "pretrain", Tags: []Tag{{Key: "team", Value: "ml"}}},
+	}))
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	summarize := func(resources []TaggedResource) []string {
+		var got []string
+		for _, r := range resources {
+			got = append(got, fmt.Sprintf("%s/%s/%s", r.Kind, r.ID, r.Name))
+		}
+		return got
+	}
+
+	t.Run("all kinds", func(t *testing.T) {
+		resources, err := client.Tags.Find(ctx, "team=ml,env!=prod")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"instance/i1/train", "volume/v1/dataset", "cluster/c1/pretrain"}
+		if got := summarize(resources); !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+		if resources[0].Instance == nil || resources[1].Volume == nil || resources[2].Cluster == nil {
+			t.Error("expected the typed resource to be set")
+		}
+	})
+
+	t.Run("selected kinds", func(t *testing.T) {
+		mockServer.SetHandler(http.MethodGet, "/clusters", func(w http.ResponseWriter, r *http.Request) {
+			t.Error("clusters should not be listed")
+		})
+		resources, err := client.Tags.Find(ctx, "team", ResourceKindInstance, ResourceKindVolume)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"instance/i1/train", "instance/i2/serve", "volume/v1/dataset"}
+		if got := summarize(resources); !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		if _, err := client.Tags.Find(ctx, "env in (dev"); err == nil {
+			t.Error("expected an error for an invalid selector")
+		}
+		if _, err := client.Tags.Find(ctx, "team", ResourceKind("bucket")); err == nil {
+			t.Error("expected an error for an unknown kind")
+		}
+	})
+}
+
+// storedTags returns the tags the mock server holds for a resource path
+func storedTags(mockServer *testutil.MockServer, resourcePath string) []Tag {
+	var tags []Tag
+	for _, tag := range mockServer.ResourceTags(resourcePath) {
+		tags = append(tags, Tag(tag))
+	}
+	return tags
+}
+
+func TestInstanceService_SetTags(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	seed := func(t *testing.T, id string, tags ...TagRequest) {
+		t.Helper()
+		for _, tag := range tags {
+			if _, err := client.Instances.AddTag(ctx, id, tag); err != nil {
+				t.Fatalf("unexpected error adding tag: %v", err)
+			}
+		}
+	}
+	keys := func(tags []Tag) []string {
+		var keys []string
+		for _, tag := range tags {
+			keys = append(keys, tag.Key+"="+tag.Value)
+		}
+		slices.Sort(keys)
+		return keys
+	}
+
+	t.Run("applies the minimal changes", func(t *testing.T) {
+		id := "instance_set_1"
+		seed(t, id,
+			TagRequest{Key: "team", Value: "ml"},
+			TagRequest{Key: "env", Value: "dev"},
+			TagRequest{Key: "owner", Value: "alice"},
+		)
+		before := storedTags(mockServer, "/instances/"+id)
+
+		changes, err := client.Instances.SetTags(ctx, id, map[string]string{"Team": "ml", "env": "prod", "gpu": ""})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := keys(changes.Added); !slices.Equal(got, []string{"gpu="}) {
+			t.Errorf("expected gpu to be added, got %v", got)
+		}
+		if got := keys(changes.Updated); !slices.Equal(got, []string{"env=prod"}) {
+			t.Errorf("expected env to be updated, got %v", got)
+		}
+		if got := keys(changes.Removed); !slices.Equal(got, []string{"owner=alice"}) {
+			t.Errorf("expected owner to be removed, got %v", got)
+		}
+
+		after := storedTags(mockServer, "/instances/"+id)
+		if got := keys(after); !slices.Equal(got, []string{"env=prod", "gpu=", "team=ml"}) {
+			t.Errorf("unexpected tags after SetTags: %v", got)
+		}
+		if after[0].ID != before[0].ID {
+			t.Error("expected the unchanged team tag to be left alone")
+		}
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		id := "instance_set_2"
+		seed(t, id, TagRequest{Key: "team", Value: "ml"})
+		changes, err := client.Instances.SetTags(ctx, id, map[string]string{"team": "ml"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if changes.Changed() {
+			t.Errorf("expected no changes, got %+v", changes)
+		}
+	})
+
+	t.Run("limits are checked first", func(t *testing.T) {
+		tooMany := make(map[string]string)
+		for i := range MaxTagsPerResource + 1 {
+			tooMany[fmt.Sprintf("k%d", i)] = ""
+		}
+		for name, desired := range map[string]map[string]string{
+			"too many tags":  tooMany,
+			"key too long":   {strings.Repeat("k", TagKeyMaxLength+1): ""},
+			"value too long": {"k": strings.Repeat("v", TagValueMaxLength+1)},
+			"empty key":      {"": "v"},
+			"duplicate key":  {"team": "a", "TEAM": "b"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				id := "instance_set_3"
+				seed(t, id, TagRequest{Key: "keep"})
+				if _, err := client.Instances.SetTags(ctx, id, desired); err == nil {
+					t.Error("expected a validation error")
+				}
+				if got := keys(storedTags(mockServer, "/instances/"+id)); !slices.Equal(got, []string{"keep="}) {
+					t.Errorf("expected tags to be untouched, got %v", got)
+				}
+				_ = client.Instances.DeleteTag(ctx, id, "keep")
+			})
+		}
+	})
+
+	t.Run("a failed update is reported as a removal", func(t *testing.T) {
+		id := "instance_set_4"
+		seed(t, id, TagRequest{Key: "env", Value: "dev"})
+		mockServer.SetHandler(http.MethodPost, "/instances/"+id+"/tags", func(w http.ResponseWriter, r *http.Request) {
+			testutil.ErrorResponse(w, http.StatusBadRequest, "rejected")
+		})
+
+		changes, err := client.Instances.SetTags(ctx, id, map[string]string{"env": "prod"})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if got := keys(changes.Removed); !slices.Equal(got, []string{"env=dev"}) {
+			t.Errorf("expected env to be reported removed, got %v", got)
+		}
+	})
+
+	t.Run("each request gets its own idempotency key", func(t *testing.T) {
+		id := "instance_set_5"
+		seed(t, id, TagRequest{Key: "env", Value: "dev"}, TagRequest{Key: "owner", Value: "alice"})
+
+		var seen []string
+		record := func(w http.ResponseWriter, r *http.Request) {
+			seen = append(seen, r.Header.Get(IdempotencyKeyHeader))
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			var req TagRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			writeTestJSON(w, Tag{ID: "tag_" + req.Key, Key: req.Key, Value: req.Value})
+		}
+		mockServer.SetHandler(http.MethodDelete, "/instances/"+id+"/tags/env", record)
+		mockServer.SetHandler(http.MethodDelete, "/instances/"+id+"/tags/owner", record)
+		mockServer.SetHandler(http.MethodPost, "/instances/"+id+"/tags", record)
+
+		keyCtx := WithIdempotencyKey(ctx, "set-1")
+		if _, err := client.Instances.SetTags(keyCtx, id, map[string]string{"env": "prod", "gpu": "a100"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{
+			"set-1-instance_set_5-delete-env",
+			"set-1-instance_set_5-delete-owner",
+			"set-1-instance_set_5-add-env",
+			"set-1-instance_set_5-add-gpu",
+		}
+		if !slices.Equal(seen, want) {
+			t.Errorf("expected keys %v, got %v", want, seen)
+		}
+	})
+}
+
+func TestTagService_SetTags(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	mockServer.SetHandler(http.MethodGet, "/volumes/vol_1", func(w http.ResponseWriter, r *http.Request) {
+		jsonHandler(t, Volume{ID: "vol_1", Tags: storedTags(mockServer, "/volumes/vol_1")})(w, r)
+	})
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	var resources []ResourceRef
+	for i := range 6 {
+		resources = append(resources, ResourceRef{Kind: ResourceKindInstance, ID: fmt.Sprintf("instance_bulk_%d", i)})
+	}
+	resources = append(resources,
+		ResourceRef{Kind: ResourceKindVolume, ID: "vol_1"},
+		ResourceRef{Kind: ResourceKindCluster, ID: "cluster_1"},
+		ResourceRef{Kind: "bucket", ID: "b_1"},
+	)
+
+	desired := map[string]string{"team": "ml"}
+	results, err := client.Tags.SetTags(ctx, resources, desired, &BulkTagOptions{Concurrency: 2})
+	if err == nil || !strings.Contains(err.Error(), "bucket b_1") {
+		t.Errorf("expected an error for the unknown kind, got %v", err)
+	}
+	if len(results) != len(resources) {
+		t.Fatalf("expected %d results, got %d", len(resources), len(results))
+	}
+	for i, result := range results[:len(results)-1] {
+		if result.Resource != resources[i] {
+			t.Errorf("expected result %d for %v, got %v", i, resources[i], result.Resource)
+		}
+		if result.Err != nil || len(result.Changes.Added) != 1 {
+			t.Errorf("expected %v to be tagged, got %+v", result.Resource, result)
+		}
+	}
+	if tags := mockServer.ResourceTags("/clusters/cluster_1"); len(tags) != 1 {
+		t.Errorf("expected the cluster to be tagged, got %v", tags)
+	}
+
+	if _, err := client.Tags.SetTags(ctx, resources, map[string]string{"": "x"}, nil); err == nil {
+		t.Error("expected invalid tags to be rejected")
+	}
+
+	t.Run("cancellation stops handing out work", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		mockServer.SetHandler(http.MethodGet, "/instances/instance_cancel_0", func(w http.ResponseWriter, r *http.Request) {
+			cancel()
+			writeTestJSON(w, Instance{ID: "instance_cancel_0"})
+		})
+		var started int
+		for i := 1; i < 4; i++ {
+			mockServer.SetHandler(http.MethodGet, fmt.Sprintf("/instances/instance_cancel_%d", i), func(w http.ResponseWriter, r *http.Request) {
+				started++
+				writeTestJSON(w, Instance{})
+			})
+		}
+
+		var refs []ResourceRef
+		for i := range 4 {
+			refs = append(refs, ResourceRef{Kind: ResourceKindInstance, ID: fmt.Sprintf("instance_cancel_%d", i)})
+		}
+		results, err := client.Tags.SetTags(ctx, refs, desired, &BulkTagOptions{Concurrency: 1})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if started != 0 {
+			t.Errorf("expected no resource to start after cancellation, got %d", started)
+		}
+		for _, result := range results[1:] {
+			if !errors.Is(result.Err, context.Canceled) || result.Changes == nil || result.Changes.Changed() {
+				t.Errorf("expected %v to be left untouched with context.Canceled, got %+v", result.Resource, result)
+			}
+		}
+	})
+}
@@ -49,3 +49,73 @@ func (r TagRequest) Validate() error {
 		validation.Field(&r.Value, validation.Length(0, TagValueMaxLength)),
 	)
 }
+
+// ResourceKind is a kind of resource that carries tags
+type ResourceKind string
+
+// Resource kinds
+const (
+	ResourceKindInstance ResourceKind = "instance"
+	ResourceKindVolume   ResourceKind = "volume"
+	ResourceKindCluster  ResourceKind = "cluster"
+)
+
+// TaggedResource is a resource found by TagService.Find. Instance, Volume or
+// Cluster is set according to Kind.
+type TaggedResource struct {
+	Kind ResourceKind
+	ID   string
+	// Name is the hostname of instances and clusters and the name of volumes
+	Name     string
+	Tags     []Tag
+	Instance *Instance
+	Volume   *Volume
+	Cluster  *Cluster
+}
+
+// DefaultBulkConcurrency is the number of resources TagService.SetTags updates
+// at a time by default
+const DefaultBulkConcurrency = 4
+
+// TagChanges summarizes the changes SetTags made to a resource
+type TagChanges struct {
+	// Added are the tags that were created
+	Added []Tag
+	// Updated are the tags whose value changed. The API cannot update a
+	// tag, so each was deleted and added again.
+	Updated []Tag
+	// Removed are the tags that were deleted
+	Removed []Tag
+}
+
+// Changed reports whether any tag was added, updated or removed
+func (c *TagChanges) Changed() bool {
+	return len(c.Added)+len(c.Updated)+len(c.Removed) > 0
+}
+
+// ResourceRef identifies a resource that carries tags
+type ResourceRef struct {
+	Kind ResourceKind
+	ID   string
+}
+
+// Ref returns the reference to r, e.g. to pass the results of
+// TagService.Find to TagService.SetTags
+func (r TaggedResource) Ref() ResourceRef {
+	return ResourceRef{Kind: r.Kind, ID: r.ID}
+}
+
+// SetTagsResult is the outcome of TagService.SetTags for one resource
+type SetTagsResult struct {
+	Resource ResourceRef
+	// Changes holds the changes made, even when Err is set
+	Changes *TagChanges
+	Err     error
+}
+
+// BulkTagOptions configures TagService.SetTags
+type BulkTagOptions struct {
+	// Concurrency is the number of resources updated at a time. Defaults to
+	// DefaultBulkConcurrency.
+	Concurrency int
+}
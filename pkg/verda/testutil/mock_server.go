@@ -481,23 +481,37 @@ const (
 	pathOAuth2Token = "/oauth2/token"
 )
 
+// idempotencyKeyHeader must match verda.IdempotencyKeyHeader
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// recordedResponse is a response replayed for a repeated idempotency key
+type recordedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
 // MockServer provides a test HTTP server for mocking Verda API responses
 type MockServer struct {
-	server   *httptest.Server
-	handlers map[string]http.HandlerFunc
-	mu       sync.RWMutex
-	sshKeys  map[string]SSHKey        // Store created SSH keys
-	scripts  map[string]StartupScript // Store created startup scripts
-	tags     map[string][]Tag         // Store tags per "<basePath>/<resourceID>"
+	server          *httptest.Server
+	handlers        map[string]http.HandlerFunc
+	mu              sync.RWMutex
+	sshKeys         map[string]SSHKey           // Store created SSH keys
+	scripts         map[string]StartupScript    // Store created startup scripts
+	tags            map[string][]Tag            // Store tags per "<basePath>/<resourceID>"
+	idempotent      map[string]recordedResponse // Responses per "<method> <path> <key>"
+	idempotencyHits map[string]int              // Requests seen per idempotency key
 }
 
 // NewMockServer creates a new mock server
 func NewMockServer() *MockServer {
 	ms := &MockServer{
-		handlers: make(map[string]http.HandlerFunc),
-		sshKeys:  make(map[string]SSHKey),
-		scripts:  make(map[string]StartupScript),
-		tags:     make(map[string][]Tag),
+		handlers:        make(map[string]http.HandlerFunc),
+		sshKeys:         make(map[string]SSHKey),
+		scripts:         make(map[string]StartupScript),
+		tags:            make(map[string][]Tag),
+		idempotent:      make(map[string]recordedResponse),
+		idempotencyHits: make(map[string]int),
 	}
 
 	ms.server = httptest.NewServer(http.HandlerFunc(ms.handleRequest))
@@ -522,10 +536,75 @@ func (ms *MockServer) SetHandler(method, path string, handler http.HandlerFunc)
 	ms.handlers[key] = handler
 }
 
-// handleRequest routes requests to appropriate handlers
+// handleRequest deduplicates mutating requests carrying an Idempotency-Key
+// header, then routes everything else to the appropriate handler
+func (ms *MockServer) handleRequest(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key == "" || !isMutatingMethod(r.Method) {
+		ms.route(w, r)
+		return
+	}
+
+	// Keys are scoped to the method and path, like the real API
+	scopedKey := r.Method + " " + r.URL.Path + " " + key
+
+	ms.mu.Lock()
+	ms.idempotencyHits[key]++
+	recorded, seen := ms.idempotent[scopedKey]
+	ms.mu.Unlock()
+
+	if seen {
+		for name, values := range recorded.header {
+			w.Header()[name] = values
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(recorded.status)
+		writeBytes(w, recorded.body)
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	ms.route(rec, r)
+
+	// Server errors are not recorded so that a retry reaches the handler again
+	if rec.Code < http.StatusInternalServerError {
+		ms.mu.Lock()
+		ms.idempotent[scopedKey] = recordedResponse{
+			status: rec.Code,
+			header: rec.Header().Clone(),
+			body:   rec.Body.Bytes(),
+		}
+		ms.mu.Unlock()
+	}
+
+	for name, values := range rec.Header() {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(rec.Code)
+	writeBytes(w, rec.Body.Bytes())
+}
+
+// IdempotencyKeyRequests returns how many requests were received with the given
+// idempotency key, including replays. Intended for assertions in tests.
+func (ms *MockServer) IdempotencyKeyRequests(key string) int {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.idempotencyHits[key]
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// route routes requests to appropriate handlers
 //
 //nolint:gocyclo // Mock server router naturally has high complexity
-func (ms *MockServer) handleRequest(w http.ResponseWriter, r *http.Request) {
+func (ms *MockServer) route(w http.ResponseWriter, r *http.Request) {
 	ms.mu.RLock()
 	key := r.Method + " " + r.URL.Path
 	handler, exists := ms.handlers[key]
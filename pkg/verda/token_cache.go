@@ -0,0 +1,238 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TokenCache keeps tokens between processes, so that short-lived programs
+// reuse a valid token, or its refresh token, instead of authenticating on
+// every run. Keys identify the API base URL and client ID.
+type TokenCache interface {
+	// Load returns the cached token for key, or nil if there is none
+	Load(key string) (*TokenResponse, error)
+	Store(key string, token *TokenResponse) error
+	Delete(key string) error
+}
+
+// LockingTokenCache is a TokenCache that can stay locked while a token is
+// fetched, so that processes sharing it refresh one at a time instead of all
+// at once. AuthService uses Update whenever its cache implements it.
+type LockingTokenCache interface {
+	TokenCache
+	// Update calls refresh with the cached token for key, or nil, while
+	// holding the lock for key, and stores the token refresh returns unless
+	// it is the cached one. An error from refresh is returned as is; if
+	// storing fails, the new token is returned together with the error.
+	Update(key string, refresh func(cached *TokenResponse) (*TokenResponse, error)) (*TokenResponse, error)
+}
+
+// WithTokenCache makes AuthService load tokens from cache and store the
+// tokens it fetches there
+func WithTokenCache(cache TokenCache) ClientOption {
+	return func(c *Client) {
+		c.TokenCache = cache
+	}
+}
+
+// FileTokenCache stores each token in its own file, readable only by the
+// current user. Files are locked while read or written, so concurrent
+// processes never see a partial token, and for the whole of Update, so that
+// they never refresh the same token at once.
+type FileTokenCache struct {
+	dir string
+}
+
+// DefaultTokenCacheDir returns the verda/tokens directory under the user
+// config directory, e.g. ~/.config/verda/tokens on Linux
+func DefaultTokenCacheDir() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tokens"), nil
+}
+
+// NewFileTokenCache returns a cache storing tokens in dir, or in
+// DefaultTokenCacheDir when dir is empty
+func NewFileTokenCache(dir string) (*FileTokenCache, error) {
+	if dir == "" {
+		var err error
+		if dir, err = DefaultTokenCacheDir(); err != nil {
+			return nil, err
+		}
+	}
+	return &FileTokenCache{dir: dir}, nil
+}
+
+// cachedToken is the on-disk form of a token
+type cachedToken struct {
+	Key          string    `json:"key"`
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func (c *FileTokenCache) Load(key string) (*TokenResponse, error) {
+	path := c.path(key)
+	if _, err := os.Stat(c.dir); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return c.load(key)
+}
+
+func (c *FileTokenCache) Store(key string, token *TokenResponse) error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create token cache directory: %w", err)
+	}
+	unlock, err := lockFile(c.path(key) + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return c.store(key, token)
+}
+
+func (c *FileTokenCache) Update(key string, refresh func(cached *TokenResponse) (*TokenResponse, error)) (*TokenResponse, error) {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create token cache directory: %w", err)
+	}
+	unlock, err := lockFile(c.path(key) + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	cached, err := c.load(key)
+	if err != nil {
+		return nil, err
+	}
+	token, err := refresh(cached)
+	if err != nil || token == nil || token == cached {
+		return token, err
+	}
+	return token, c.store(key, token)
+}
+
+// load reads the token for key; the caller holds its lock
+func (c *FileTokenCache) load(key string) (*TokenResponse, error) {
+	path := c.path(key)
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is derived from a hash inside the cache directory
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached token: %w", err)
+	}
+
+	var cached cachedToken
+	if err := json.Unmarshal(data, &cached); err != nil || cached.Key != key {
+		// A corrupt or foreign file is treated as a miss and overwritten later
+		return nil, nil
+	}
+	return &TokenResponse{
+		AccessToken:  cached.AccessToken,
+		RefreshToken: cached.RefreshToken,
+		TokenType:    cached.TokenType,
+		Scope:        cached.Scope,
+		ExpiresIn:    max(int(time.Until(cached.ExpiresAt).Seconds()), 0),
+		ExpiresAt:    cached.ExpiresAt,
+	}, nil
+}
+
+// store writes the token for key; the caller holds its lock
+func (c *FileTokenCache) store(key string, token *TokenResponse) error {
+	data, err := json.Marshal(cachedToken{ //nolint:gosec // G117: the cache exists to persist the token
+		Key:          key,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		Scope:        token.Scope,
+		ExpiresAt:    token.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode token: %w", err)
+	}
+
+	// Write to a temporary file and rename, so a crash never leaves half a token
+	tmp, err := os.CreateTemp(c.dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cached token: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write cached token: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write cached token: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cached token: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to write cached token: %w", err)
+	}
+	return nil
+}
+
+func (c *FileTokenCache) Delete(key string) error {
+	path := c.path(key)
+	if _, err := os.Stat(c.dir); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete cached token: %w", err)
+	}
+	return nil
+}
+
+// path names the file for key; keys are hashed so that URLs and client IDs
+// never end up in file names
+func (c *FileTokenCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// tokenCacheKey identifies the tokens of one client at one API
+func tokenCacheKey(baseURL, clientID string) string {
+	return baseURL + " " + clientID
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func TestFileTokenCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tokens")
+	cache, err := NewFileTokenCache(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("missing entries are a miss", func(t *testing.T) {
+		token, err := cache.Load("https://api.verda.com/v1 id")
+		if token != nil || err != nil {
+			t.Errorf("expected a miss, got %+v, %v", token, err)
+		}
+	})
+
+	t.Run("tokens round trip per key", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+		for _, id := range []string{"a", "b"} {
+			token := &TokenResponse{AccessToken: "access-" + id, RefreshToken: "refresh-" + id, ExpiresAt: expiresAt}
+			if err := cache.Store("base "+id, token); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		token, err := cache.Load("base a")
+		if err != nil || token == nil {
+			t.Fatalf("expected a hit, got %v", err)
+		}
+		if token.AccessToken != "access-a" || token.RefreshToken != "refresh-a" || !token.ExpiresAt.Equal(expiresAt) {
+			t.Errorf("unexpected token %+v", token)
+		}
+	})
+
+	t.Run("files are private to the user", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("permission bits are not enforced on Windows")
+		}
+		info, err := os.Stat(cache.path("base a"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mode := info.Mode().Perm(); mode != 0o600 {
+			t.Errorf("expected mode 0600, got %o", mode)
+		}
+		if info, _ := os.Stat(dir); info.Mode().Perm() != 0o700 {
+			t.Errorf("expected directory mode 0700, got %o", info.Mode().Perm())
+		}
+	})
+
+	t.Run("delete removes the entry", func(t *testing.T) {
+		if err := cache.Delete("base b"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token, _ := cache.Load("base b"); token != nil {
+			t.Errorf("expected a miss after delete, got %+v", token)
+		}
+	})
+
+	t.Run("updates are serialised", func(t *testing.T) {
+		var wg sync.WaitGroup
+		var refreshes int32
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = cache.Update("base d", func(cached *TokenResponse) (*TokenResponse, error) {
+					if cached != nil {
+						return cached, nil
+					}
+					n := atomic.AddInt32(&refreshes, 1)
+					time.Sleep(10 * time.Millisecond)
+					return &TokenResponse{AccessToken: fmt.Sprintf("access-%d", n)}, nil
+				})
+			}()
+		}
+		wg.Wait()
+
+		if n := atomic.LoadInt32(&refreshes); n != 1 {
+			t.Errorf("expected 1 refresh, got %d", n)
+		}
+		if token, _ := cache.Load("base d"); token == nil || token.AccessToken != "access-1" {
+			t.Errorf("expected the refreshed token to be stored, got %+v", token)
+		}
+	})
+
+	t.Run("concurrent writers never leave a partial file", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = cache.Store("base c", &TokenResponse{AccessToken: fmt.Sprintf("access-%d", i)})
+			}()
+		}
+		wg.Wait()
+
+		data, err := os.ReadFile(cache.path("base c"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !json.Valid(data) {
+			t.Errorf("expected a complete JSON document, got %s", data)
+		}
+	})
+}
+
+func TestClient_TokenCache(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var calls int32
+	var grants []string
+	mockServer.SetHandler(http.MethodPost, "/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		var req TokenRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		grants = append(grants, req.GrantType)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","refresh_token":"refresh-%d","token_type":"Bearer","expires_in":3600}`, n, n)
+	})
+
+	cache, _ := NewFileTokenCache(t.TempDir())
+	newClient := func() *Client {
+		return newRateLimitedTestClient(mockServer, WithTokenCache(cache))
+	}
+	ctx := context.Background()
+
+	t.Run("a new client reuses the cached token", func(t *testing.T) {
+		for range 3 {
+			if _, err := newClient().Balance.Get(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("expected 1 token request across clients, got %d", n)
+		}
+	})
+
+	t.Run("an expired cached token is refreshed", func(t *testing.T) {
+		client := newClient()
+		key := client.Auth.cacheKey()
+		stored, _ := cache.Load(key)
+		stored.ExpiresAt = time.Now().Add(-time.Minute)
+		if err := cache.Store(key, stored); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		token, err := client.Auth.GetValidTokenContext(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token.AccessToken != "token-2" || grants[len(grants)-1] != "refresh_token" {
+			t.Errorf("expected a refresh grant, got %q after %v", token.AccessToken, grants)
+		}
+		if cached, _ := cache.Load(key); cached == nil || cached.AccessToken != "token-2" {
+			t.Errorf("expected the refreshed token to be cached, got %+v", cached)
+		}
+	})
+
+	t.Run("clients sharing the cache refresh once", func(t *testing.T) {
+		key := newClient().Auth.cacheKey()
+		stored, _ := cache.Load(key)
+		stored.ExpiresAt = time.Now().Add(-time.Minute)
+		if err := cache.Store(key, stored); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		before := atomic.LoadInt32(&calls)
+
+		var wg sync.WaitGroup
+		tokens := make([]string, 5)
+		for i := range tokens {
+			client := newClient()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if token, err := client.Auth.GetValidTokenContext(ctx); err == nil {
+					tokens[i] = token.AccessToken
+				}
+			}()
+		}
+		wg.Wait()
+
+		if n := atomic.LoadInt32(&calls) - before; n != 1 {
+			t.Errorf("expected 1 token request across clients, got %d", n)
+		}
+		for _, token := range tokens {
+			if token != tokens[0] || token == stored.AccessToken {
+				t.Errorf("expected every client to get the same new token, got %v", tokens)
+				break
+			}
+		}
+	})
+
+	t.Run("a rejected token is removed from the cache", func(t *testing.T) {
+		client := newClient()
+		token, _ := client.Auth.GetValidTokenContext(ctx)
+		client.Auth.Invalidate(token.AccessToken)
+
+		if cached, _ := cache.Load(client.Auth.cacheKey()); cached != nil {
+			t.Errorf("expected the cache entry to be deleted, got %+v", cached)
+		}
+	})
+}
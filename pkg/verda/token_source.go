@@ -0,0 +1,164 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Token is an access token for the API. Its fields match those of
+// golang.org/x/oauth2.Token; see the oauth2verda package for conversions.
+type Token struct {
+	AccessToken string
+	// TokenType is the authorization scheme, "Bearer" when empty
+	TokenType    string
+	RefreshToken string
+	// Expiry is when the token expires; the zero time means it never does
+	Expiry time.Time
+}
+
+// Type returns the authorization scheme of the token
+func (t *Token) Type() string {
+	if t.TokenType == "" || strings.EqualFold(t.TokenType, "bearer") {
+		return "Bearer"
+	}
+	return t.TokenType
+}
+
+// Valid reports whether the token is set and not about to expire
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" &&
+		(t.Expiry.IsZero() || time.Now().Add(30*time.Second).Before(t.Expiry))
+}
+
+// TokenSource supplies the access token for each request. It is the
+// context-aware equivalent of golang.org/x/oauth2.TokenSource. One source may
+// be shared by many clients and must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// InvalidatingTokenSource is a TokenSource that can discard a token the API
+// rejected. When a request gets a 401, the client calls Invalidate and, if it
+// returns true, replays the request once with a new token.
+type InvalidatingTokenSource interface {
+	TokenSource
+	Invalidate(accessToken string) bool
+}
+
+// TokenSourceFunc adapts a callback, e.g. one that asks a secrets broker for
+// a short-lived token, to a TokenSource
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource returns a TokenSource that always returns the same
+// pre-issued bearer token
+func StaticTokenSource(accessToken string) TokenSource {
+	token := &Token{AccessToken: accessToken, TokenType: "Bearer"}
+	return TokenSourceFunc(func(context.Context) (*Token, error) {
+		return token, nil
+	})
+}
+
+// NewClientCredentialsTokenSource returns a TokenSource that fetches and
+// refreshes tokens with the client credentials grant. Options such as
+// WithBaseURL, WithHTTPClient, WithRateLimit and WithCircuitBreaker configure
+// the token requests, which go through the same transport, rate limits and
+// circuit breaker as API requests. The source can be shared by clients through
+// WithTokenSource.
+func NewClientCredentialsTokenSource(clientID, clientSecret string, options ...ClientOption) (*AuthService, error) {
+	if clientID == "" {
+		return nil, fmt.Errorf("client ID is required")
+	}
+	if clientSecret == "" {
+		return nil, fmt.Errorf("client secret is required")
+	}
+
+	client, err := newTransportClient(append(options, WithClientID(clientID), WithClientSecret(clientSecret))...)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthService{client: client}, nil
+}
+
+// WithTokenSource makes the client authenticate requests with tokens from
+// source instead of its own client credentials
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(c *Client) {
+		c.TokenSource = source
+	}
+}
+
+// WithStaticToken authenticates every request with a pre-issued bearer token
+// instead of the client credentials flow
+func WithStaticToken(accessToken string) ClientOption {
+	return WithTokenSource(StaticTokenSource(accessToken))
+}
+
+// Token returns a valid token, fetching or refreshing one when needed
+func (s *AuthService) Token(ctx context.Context) (*Token, error) {
+	token, err := s.GetValidTokenContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		AccessToken:  token.AccessToken,
+		TokenType:    token.TokenType,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.ExpiresAt,
+	}, nil
+}
+
+// authorization returns the Authorization header value for a request
+func (c *Client) authorization(ctx context.Context) (string, error) {
+	token, err := c.tokenSource().Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == nil {
+		return "", errors.New("empty authentication token")
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("token source returned a token without an access token")
+	}
+	return token.Type() + " " + token.AccessToken, nil
+}
+
+// invalidateToken passes the token of a rejected request back to the token
+// source. It reports whether the request is worth replaying.
+func (c *Client) invalidateToken(authorization string) bool {
+	source, ok := c.tokenSource().(InvalidatingTokenSource)
+	if !ok {
+		return false
+	}
+	_, accessToken, found := strings.Cut(authorization, " ")
+	return found && source.Invalidate(accessToken)
+}
+
+// tokenSource returns the client's TokenSource, falling back to Auth for
+// clients built without NewClient
+func (c *Client) tokenSource() TokenSource {
+	if c.TokenSource != nil {
+		return c.TokenSource
+	}
+	return c.Auth
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+// authRecorder serves /balance and records the Authorization headers it saw
+func authRecorder(mockServer *testutil.MockServer, status int) *[]string {
+	var seen []string
+	mockServer.SetHandler(http.MethodGet, "/balance", func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		if status != http.StatusOK {
+			testutil.ErrorResponse(w, status, http.StatusText(status))
+			return
+		}
+		_, _ = w.Write([]byte(`{"amount":1,"currency":"eur"}`))
+	})
+	return &seen
+}
+
+func TestTokenSource(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	var tokenCalls int32
+	mockServer.SetHandler(http.MethodPost, "/oauth2/token", tokenHandler(&tokenCalls, nil))
+	ctx := context.Background()
+
+	t.Run("static token skips the token endpoint", func(t *testing.T) {
+		seen := authRecorder(mockServer, http.StatusOK)
+		client, err := NewClient(WithBaseURL(mockServer.URL()), WithStaticToken("pre-issued"))
+		if err != nil {
+			t.Fatalf("expected no client credentials to be needed, got %v", err)
+		}
+
+		if _, err := client.Balance.Get(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(*seen) != 1 || (*seen)[0] != "Bearer pre-issued" || atomic.LoadInt32(&tokenCalls) != 0 {
+			t.Errorf("expected only the static token, got %v and %d token calls", *seen, tokenCalls)
+		}
+	})
+
+	t.Run("callback is asked for every request", func(t *testing.T) {
+		seen := authRecorder(mockServer, http.StatusOK)
+		var calls int
+		source := TokenSourceFunc(func(context.Context) (*Token, error) {
+			calls++
+			return &Token{AccessToken: fmt.Sprintf("broker-%d", calls), TokenType: "bearer"}, nil
+		})
+		client, _ := NewClient(WithBaseURL(mockServer.URL()), WithTokenSource(source))
+
+		for range 2 {
+			if _, err := client.Balance.Get(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if len(*seen) != 2 || (*seen)[0] != "Bearer broker-1" || (*seen)[1] != "Bearer broker-2" {
+			t.Errorf("expected a fresh token per request, got %v", *seen)
+		}
+	})
+
+	t.Run("auth bearer token only authorises the token endpoint", func(t *testing.T) {
+		seen := authRecorder(mockServer, http.StatusOK)
+		var tokenAuth string
+		mockServer.SetHandler(http.MethodPost, "/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+			tokenAuth = r.Header.Get("Authorization")
+			tokenHandler(&tokenCalls, nil)(w, r)
+		})
+		defer mockServer.SetHandler(http.MethodPost, "/oauth2/token", tokenHandler(&tokenCalls, nil))
+
+		if _, err := NewClient(WithBaseURL(mockServer.URL()), WithAuthBearerToken("gateway")); err == nil {
+			t.Fatal("expected client credentials to still be required")
+		}
+		client, err := NewClient(WithBaseURL(mockServer.URL()), WithClientID("id"), WithClientSecret("secret"), WithAuthBearerToken("gateway"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := client.Balance.Get(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tokenAuth != "Bearer gateway" || len(*seen) != 1 || !strings.HasPrefix((*seen)[0], "Bearer token-") {
+			t.Errorf("expected the gateway token on the token request only, got %q and %v", tokenAuth, *seen)
+		}
+	})
+
+	t.Run("rejected tokens are not replayed without Invalidate", func(t *testing.T) {
+		seen := authRecorder(mockServer, http.StatusUnauthorized)
+		client, _ := NewClient(WithBaseURL(mockServer.URL()), WithStaticToken("revoked"))
+
+		if _, err := client.Balance.Get(ctx); err == nil {
+			t.Fatal("expected the 401 to be returned")
+		}
+		if len(*seen) != 1 {
+			t.Errorf("expected a single request, got %d", len(*seen))
+		}
+	})
+
+	t.Run("tokens without an access token are rejected", func(t *testing.T) {
+		seen := authRecorder(mockServer, http.StatusOK)
+		source := TokenSourceFunc(func(context.Context) (*Token, error) {
+			return &Token{TokenType: "bearer"}, nil
+		})
+		client, _ := NewClient(WithBaseURL(mockServer.URL()), WithTokenSource(source))
+
+		_, err := client.Balance.Get(ctx)
+		if err == nil || !strings.Contains(err.Error(), "without an access token") {
+			t.Fatalf("expected a missing access token error, got %v", err)
+		}
+		if len(*seen) != 0 {
+			t.Errorf("expected no request to be sent, got %v", *seen)
+		}
+	})
+
+	t.Run("client credentials source is shared between clients", func(t *testing.T) {
+		seen := authRecorder(mockServer, http.StatusOK)
+		atomic.StoreInt32(&tokenCalls, 0)
+		source, err := NewClientCredentialsTokenSource("id", "secret", WithBaseURL(mockServer.URL()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for range 2 {
+			client, _ := NewClient(WithBaseURL(mockServer.URL()), WithTokenSource(source))
+			if _, err := client.Balance.Get(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if atomic.LoadInt32(&tokenCalls) != 1 || (*seen)[0] != (*seen)[1] {
+			t.Errorf("expected one token for both clients, got %d token calls and %v", tokenCalls, *seen)
+		}
+	})
+
+	t.Run("client credentials source needs both credentials", func(t *testing.T) {
+		if _, err := NewClientCredentialsTokenSource("id", ""); err == nil {
+			t.Error("expected a missing client secret to be rejected")
+		}
+	})
+
+	t.Run("token requests go through the circuit breaker", func(t *testing.T) {
+		atomic.StoreInt32(&tokenCalls, 0)
+		cb := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute})
+		cb.Record("/oauth2", false)
+		source, err := NewClientCredentialsTokenSource("id", "secret", WithBaseURL(mockServer.URL()), WithCircuitBreaker(cb))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := source.Token(ctx); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("expected the open circuit to stop the token request, got %v", err)
+		}
+		if n := atomic.LoadInt32(&tokenCalls); n != 0 {
+			t.Errorf("expected no token request to be sent, got %d", n)
+		}
+	})
+}
+
+func TestToken(t *testing.T) {
+	if typ := (&Token{TokenType: "bearer"}).Type(); typ != "Bearer" {
+		t.Errorf("expected the scheme to be normalised, got %q", typ)
+	}
+	if !(&Token{AccessToken: "t"}).Valid() {
+		t.Error("expected a token without expiry to be valid")
+	}
+	if (*Token)(nil).Valid() || (&Token{}).Valid() {
+		t.Error("expected missing tokens to be invalid")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Span attribute and event names set by the client
+const (
+	AttrHTTPMethod     = "http.request.method"
+	AttrURLTemplate    = "url.template"
+	AttrServerAddress  = "server.address"
+	AttrHTTPStatusCode = "http.response.status_code"
+	AttrResendCount    = "http.request.resend_count"
+	AttrAPIErrorCode   = "verda.error.code"
+	AttrAttempt        = "verda.attempt"
+	AttrDNSDuration    = "verda.timing.dns"
+	AttrConnectTime    = "verda.timing.connect"
+	AttrTLSDuration    = "verda.timing.tls"
+	AttrFirstByte      = "verda.timing.first_byte"
+	AttrConnReused     = "verda.conn.reused"
+
+	// EventHTTPAttempt is added to the span once per HTTP attempt
+	EventHTTPAttempt = "http.attempt"
+)
+
+// Attribute is a key/value pair attached to a span. Values are strings,
+// bools, ints, int64s, float64s or time.Durations.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Tracer creates spans for client calls. See the otelverda package for an
+// OpenTelemetry implementation.
+type Tracer interface {
+	// Start begins a span as a child of any span in ctx
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+	// Inject writes the trace context of ctx into outgoing request headers
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is a single traced operation
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	AddEvent(name string, attrs ...Attribute)
+	// RecordError marks the span as failed
+	RecordError(err error)
+	End()
+}
+
+// WithTracer creates a span for every Client.Do call
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) {
+		c.Tracer = tracer
+	}
+}
+
+type spanKey struct{}
+
+func spanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(spanKey{}).(Span)
+	return span
+}
+
+// startSpan opens the span for a Do call and injects its trace context into
+// req. The returned request carries the span; end must be called with the
+// call's outcome.
+func (c *Client) startSpan(req *http.Request) (*http.Request, func(resp *Response, err error)) {
+	if c.Tracer == nil {
+		return req, func(*Response, error) {}
+	}
+
+	template := PathTemplate(c.relativePath(req.URL.Path))
+	ctx, span := c.Tracer.Start(req.Context(), req.Method+" "+template,
+		Attribute{Key: AttrHTTPMethod, Value: req.Method},
+		Attribute{Key: AttrURLTemplate, Value: template},
+		Attribute{Key: AttrServerAddress, Value: req.URL.Hostname()},
+	)
+	c.Tracer.Inject(ctx, req.Header)
+	req = req.WithContext(context.WithValue(ctx, spanKey{}, span))
+
+	return req, func(resp *Response, err error) {
+		if resp != nil && resp.Response != nil {
+			span.SetAttributes(Attribute{Key: AttrHTTPStatusCode, Value: resp.StatusCode})
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code != "" {
+			span.SetAttributes(Attribute{Key: AttrAPIErrorCode, Value: apiErr.Code})
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}
+}
+
+// traceAttempt attaches an httptrace.ClientTrace to a single HTTP attempt.
+// The returned func adds the attempt's timings to the span as an event.
+func traceAttempt(req *http.Request, attempt int) (*http.Request, func(resp *http.Response)) {
+	span := spanFromContext(req.Context())
+	if span == nil {
+		return req, func(*http.Response) {}
+	}
+
+	t := &attemptTimings{start: time.Now()}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), t.clientTrace()))
+
+	return req, func(resp *http.Response) {
+		attrs := append([]Attribute{{Key: AttrAttempt, Value: attempt + 1}}, t.attributes()...)
+		if resp != nil {
+			attrs = append(attrs, Attribute{Key: AttrHTTPStatusCode, Value: resp.StatusCode})
+		}
+		span.AddEvent(EventHTTPAttempt, attrs...)
+		if attempt > 0 {
+			span.SetAttributes(Attribute{Key: AttrResendCount, Value: attempt})
+		}
+	}
+}
+
+// attemptTimings collects httptrace callbacks, which may fire on other goroutines
+type attemptTimings struct {
+	mu                     sync.Mutex
+	start                  time.Time
+	dnsStart, dnsDone      time.Time
+	connectStart, connDone time.Time
+	tlsStart, tlsDone      time.Time
+	firstByte              time.Time
+	reused                 bool
+}
+
+func (t *attemptTimings) set(field *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*field = time.Now()
+}
+
+func (t *attemptTimings) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.set(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.set(&t.dnsDone) },
+		ConnectStart:         func(string, string) { t.set(&t.connectStart) },
+		ConnectDone:          func(string, string, error) { t.set(&t.connDone) },
+		TLSHandshakeStart:    func() { t.set(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.set(&t.tlsDone) },
+		GotFirstResponseByte: func() { t.set(&t.firstByte) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.reused = info.Reused
+		},
+	}
+}
+
+func (t *attemptTimings) attributes() []Attribute {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	attrs := []Attribute{{Key: AttrConnReused, Value: t.reused}}
+	add := func(key string, from, to time.Time) {
+		if !from.IsZero() && !to.IsZero() {
+			attrs = append(attrs, Attribute{Key: key, Value: to.Sub(from)})
+		}
+	}
+	add(AttrDNSDuration, t.dnsStart, t.dnsDone)
+	add(AttrConnectTime, t.connectStart, t.connDone)
+	add(AttrTLSDuration, t.tlsStart, t.tlsDone)
+	add(AttrFirstByte, t.start, t.firstByte)
+	return attrs
+}
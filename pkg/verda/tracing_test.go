@@ -0,0 +1,181 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+// fakeTracer records spans and propagates a fixed trace header
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+type fakeSpan struct {
+	mu     sync.Mutex
+	name   string
+	parent string
+	attrs  map[string]any
+	events []fakeEvent
+	err    error
+	ended  bool
+}
+
+type fakeEvent struct {
+	name  string
+	attrs map[string]any
+}
+
+type fakeTraceKey struct{}
+
+func (f *fakeTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	parent, _ := ctx.Value(fakeTraceKey{}).(string)
+	span := &fakeSpan{name: name, parent: parent, attrs: make(map[string]any)}
+	span.SetAttributes(attrs...)
+
+	f.mu.Lock()
+	f.spans = append(f.spans, span)
+	f.mu.Unlock()
+
+	return context.WithValue(ctx, fakeTraceKey{}, name), span
+}
+
+func (f *fakeTracer) Inject(ctx context.Context, header http.Header) {
+	if name, ok := ctx.Value(fakeTraceKey{}).(string); ok {
+		header.Set("X-Test-Trace", name)
+	}
+}
+
+func (s *fakeSpan) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *fakeSpan) AddEvent(name string, attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	event := fakeEvent{name: name, attrs: make(map[string]any)}
+	for _, a := range attrs {
+		event.attrs[a.Key] = a.Value
+	}
+	s.events = append(s.events, event)
+}
+
+func (s *fakeSpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *fakeSpan) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ended = true
+}
+
+func TestClient_Tracing(t *testing.T) {
+	t.Run("one span per call with attributes and attempt timings", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		var calls int32
+		var traceHeader string
+		failing := failingHandler(&calls, 1, http.StatusBadGateway, nil)
+		mockServer.SetHandler(http.MethodGet, "/instances/inst_1", func(w http.ResponseWriter, r *http.Request) {
+			traceHeader = r.Header.Get("X-Test-Trace")
+			failing(w, r)
+		})
+
+		tracer := &fakeTracer{}
+		client := newRateLimitedTestClient(mockServer,
+			WithTracer(tracer),
+			WithRetryPolicy(&RetryPolicy{MaxRetries: 2, InitialDelay: time.Millisecond}),
+		)
+
+		ctx := context.WithValue(context.Background(), fakeTraceKey{}, "caller")
+		if _, err := client.Instances.GetByID(ctx, "inst_1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(tracer.spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+		}
+		span := tracer.spans[0]
+		if span.name != "GET /instances/{id}" || span.parent != "caller" || !span.ended {
+			t.Errorf("unexpected span %q (parent %q, ended %v)", span.name, span.parent, span.ended)
+		}
+		if traceHeader != span.name {
+			t.Errorf("expected the trace context to be propagated, got %q", traceHeader)
+		}
+
+		expected := map[string]any{
+			AttrHTTPMethod:     http.MethodGet,
+			AttrURLTemplate:    "/instances/{id}",
+			AttrHTTPStatusCode: http.StatusOK,
+			AttrResendCount:    1,
+		}
+		for key, want := range expected {
+			if got := span.attrs[key]; got != want {
+				t.Errorf("attribute %s = %v, expected %v", key, got, want)
+			}
+		}
+
+		if len(span.events) != 2 {
+			t.Fatalf("expected an event per attempt, got %d", len(span.events))
+		}
+		first := span.events[0]
+		if first.name != EventHTTPAttempt || first.attrs[AttrAttempt] != 1 || first.attrs[AttrHTTPStatusCode] != http.StatusBadGateway {
+			t.Errorf("unexpected first attempt event: %+v", first)
+		}
+		if _, ok := first.attrs[AttrFirstByte].(time.Duration); !ok {
+			t.Errorf("expected a first byte timing, got %+v", first.attrs)
+		}
+	})
+
+	t.Run("API error codes are recorded", func(t *testing.T) {
+		mockServer := testutil.NewMockServer()
+		defer mockServer.Close()
+
+		mockServer.SetHandler(http.MethodGet, "/volumes/vol_1", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"volume not found"}`))
+		})
+
+		tracer := &fakeTracer{}
+		client := newRateLimitedTestClient(mockServer, WithTracer(tracer))
+		if _, err := client.Volumes.GetVolume(context.Background(), "vol_1"); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		span := tracer.spans[0]
+		if span.attrs[AttrAPIErrorCode] != "not_found" || span.attrs[AttrHTTPStatusCode] != http.StatusNotFound {
+			t.Errorf("unexpected attributes: %+v", span.attrs)
+		}
+		if span.err == nil {
+			t.Error("expected the error to be recorded")
+		}
+	})
+}
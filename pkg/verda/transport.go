@@ -0,0 +1,212 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Transport defaults used when a TransportConfig field is left at its zero value
+const (
+	// DefaultTimeout bounds a whole Client call, including retries
+	DefaultTimeout = 5 * time.Minute
+	// DefaultRequestTimeout bounds each HTTP attempt, including reading the body
+	DefaultRequestTimeout      = 60 * time.Second
+	DefaultDialTimeout         = 10 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+)
+
+// errTransportWithHTTPClient is returned by NewClient when transport options
+// are combined with WithHTTPClient, whose transport the caller owns
+var errTransportWithHTTPClient = errors.New("transport options cannot be combined with WithHTTPClient")
+
+// TransportConfig tunes the HTTP client that NewClient builds. Zero values
+// use the defaults above. It has no effect on a client passed to
+// WithHTTPClient.
+type TransportConfig struct {
+	// RequestTimeout bounds each HTTP attempt. Defaults to 60s; a negative
+	// value means no limit.
+	RequestTimeout time.Duration
+	// DialTimeout bounds opening a TCP connection. Defaults to 10s.
+	DialTimeout time.Duration
+	// TLSHandshakeTimeout bounds the TLS handshake. Defaults to 10s.
+	TLSHandshakeTimeout time.Duration
+
+	// IdleConnTimeout closes pooled connections unused for this long.
+	// Defaults to 90s.
+	IdleConnTimeout time.Duration
+	// MaxIdleConns caps pooled connections across hosts. Defaults to 100.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost caps pooled connections to the API. Defaults to 10.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost caps all connections to the API; zero means no limit
+	MaxConnsPerHost int
+
+	// Proxy picks the proxy for a request. Defaults to http.ProxyFromEnvironment,
+	// which honours HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+	Proxy func(*http.Request) (*url.URL, error)
+	// TLSConfig sets custom root CAs, client certificates for mTLS or a
+	// minimum TLS version. Defaults to the system roots and TLS 1.2.
+	TLSConfig *tls.Config
+	// DisableHTTP2 restricts connections to HTTP/1.1
+	DisableHTTP2 bool
+}
+
+// WithTimeout bounds each Client call, including its retries and any token
+// request. Defaults to DefaultTimeout; zero means no limit.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.Timeout = timeout
+	}
+}
+
+// WithTransportConfig replaces the whole transport configuration
+func WithTransportConfig(config TransportConfig) ClientOption {
+	return func(c *Client) {
+		c.Transport = config
+		c.transportSet = true
+	}
+}
+
+// WithRequestTimeout bounds each HTTP attempt. Defaults to
+// DefaultRequestTimeout; zero means no limit, as with WithTimeout.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if timeout <= 0 {
+			timeout = -1
+		}
+		c.Transport.RequestTimeout = timeout
+		c.transportSet = true
+	}
+}
+
+// WithDialTimeout bounds opening a TCP connection
+func WithDialTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.Transport.DialTimeout = timeout
+		c.transportSet = true
+	}
+}
+
+// WithTLSHandshakeTimeout bounds the TLS handshake
+func WithTLSHandshakeTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.Transport.TLSHandshakeTimeout = timeout
+		c.transportSet = true
+	}
+}
+
+// WithConnectionPool sizes the idle connection pool
+func WithConnectionPool(maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.Transport.MaxIdleConns = maxIdleConns
+		c.Transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		c.Transport.IdleConnTimeout = idleConnTimeout
+		c.transportSet = true
+	}
+}
+
+// WithProxy sends requests through proxyURL instead of the proxy from the
+// environment. Pass nil to connect directly.
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		c.Transport.Proxy = http.ProxyURL(proxyURL)
+		c.transportSet = true
+	}
+}
+
+// WithTLSConfig sets the TLS configuration, e.g. RootCAs for a private CA or
+// Certificates for mTLS
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.Transport.TLSConfig = config
+		c.transportSet = true
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2, which is on by default
+func WithHTTP2(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.Transport.DisableHTTP2 = !enabled
+		c.transportSet = true
+	}
+}
+
+// NewHTTPClient builds an *http.Client from the configuration
+func (t TransportConfig) NewHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: t.NewTransport(),
+		Timeout:   t.requestTimeout(),
+	}
+}
+
+// requestTimeout resolves RequestTimeout, where a negative value means no limit
+func (t TransportConfig) requestTimeout() time.Duration {
+	if t.RequestTimeout < 0 {
+		return 0
+	}
+	return orDefault(t.RequestTimeout, DefaultRequestTimeout)
+}
+
+// NewTransport builds an *http.Transport from the configuration
+func (t TransportConfig) NewTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   orDefault(t.DialTimeout, DefaultDialTimeout),
+		KeepAlive: 30 * time.Second,
+	}
+
+	proxy := t.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if t.TLSConfig != nil {
+		tlsConfig = t.TLSConfig.Clone()
+	}
+
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   orDefault(t.TLSHandshakeTimeout, DefaultTLSHandshakeTimeout),
+		IdleConnTimeout:       orDefault(t.IdleConnTimeout, DefaultIdleConnTimeout),
+		MaxIdleConns:          orDefault(t.MaxIdleConns, DefaultMaxIdleConns),
+		MaxIdleConnsPerHost:   orDefault(t.MaxIdleConnsPerHost, DefaultMaxIdleConnsPerHost),
+		MaxConnsPerHost:       t.MaxConnsPerHost,
+		ExpectContinueTimeout: time.Second,
+		ForceAttemptHTTP2:     !t.DisableHTTP2,
+	}
+	if t.DisableHTTP2 {
+		// A non-nil empty map turns off the transport's HTTP/2 support
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport
+}
+
+func orDefault[T time.Duration | int](value, fallback T) T {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
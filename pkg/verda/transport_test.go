@@ -0,0 +1,257 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTLSServer starts an HTTPS server with HTTP/2 enabled that answers
+// /balance and records the protocol of the last request
+func newTLSServer(t *testing.T, configure func(*tls.Config)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var protoMajor atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		protoMajor.Store(int32(r.ProtoMajor)) //nolint:gosec // G115: protocol versions are tiny
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"amount":10,"currency":"eur"}`))
+	}))
+	server.EnableHTTP2 = true
+	server.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	if configure != nil {
+		configure(server.TLS)
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server, &protoMajor
+}
+
+func serverRoots(server *httptest.Server) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	return pool
+}
+
+// newClientCertificate returns a self-signed certificate for mTLS
+func newClientCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "verda-test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func newTransportTestClient(t *testing.T, baseURL string, options ...ClientOption) *Client {
+	t.Helper()
+	client, err := NewClient(append([]ClientOption{WithBaseURL(baseURL), WithStaticToken("test-token")}, options...)...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client
+}
+
+func TestTransportDefaults(t *testing.T) {
+	client := newTransportTestClient(t, DefaultBaseURL)
+
+	if client.Timeout != DefaultTimeout {
+		t.Errorf("expected call timeout %v, got %v", DefaultTimeout, client.Timeout)
+	}
+	if client.HTTPClient.Timeout != DefaultRequestTimeout {
+		t.Errorf("expected request timeout %v, got %v", DefaultRequestTimeout, client.HTTPClient.Timeout)
+	}
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport, got %T", client.HTTPClient.Transport)
+	}
+	if transport.TLSHandshakeTimeout != DefaultTLSHandshakeTimeout || transport.IdleConnTimeout != DefaultIdleConnTimeout ||
+		transport.MaxIdleConns != DefaultMaxIdleConns || transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Errorf("unexpected transport settings %+v", transport)
+	}
+	if transport.Proxy == nil || !transport.ForceAttemptHTTP2 || transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Error("expected environment proxy, HTTP/2 and TLS 1.2 by default")
+	}
+
+	t.Run("options override defaults", func(t *testing.T) {
+		client := newTransportTestClient(t, DefaultBaseURL,
+			WithRequestTimeout(5*time.Second),
+			WithTLSHandshakeTimeout(2*time.Second),
+			WithConnectionPool(20, 4, time.Minute),
+		)
+		transport := client.HTTPClient.Transport.(*http.Transport)
+		if client.HTTPClient.Timeout != 5*time.Second || transport.TLSHandshakeTimeout != 2*time.Second ||
+			transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 4 || transport.IdleConnTimeout != time.Minute {
+			t.Errorf("unexpected transport settings %+v", transport)
+		}
+	})
+
+	t.Run("zero request timeout means no limit", func(t *testing.T) {
+		client := newTransportTestClient(t, DefaultBaseURL, WithTimeout(0), WithRequestTimeout(0))
+		if client.Timeout != 0 || client.HTTPClient.Timeout != 0 {
+			t.Errorf("expected no limits, got call timeout %v and request timeout %v", client.Timeout, client.HTTPClient.Timeout)
+		}
+	})
+
+	t.Run("transport options conflict with an explicit HTTP client", func(t *testing.T) {
+		_, err := NewClient(WithStaticToken("t"), WithHTTPClient(&http.Client{}), WithDialTimeout(time.Second))
+		if !errors.Is(err, errTransportWithHTTPClient) {
+			t.Errorf("expected errTransportWithHTTPClient, got %v", err)
+		}
+	})
+}
+
+func TestTransportTLS(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown CA is rejected", func(t *testing.T) {
+		server, _ := newTLSServer(t, nil)
+		client := newTransportTestClient(t, server.URL)
+
+		var certErr *tls.CertificateVerificationError
+		if _, err := client.Balance.Get(ctx); !errors.As(err, &certErr) {
+			t.Errorf("expected a certificate verification error, got %v", err)
+		}
+	})
+
+	t.Run("custom CA over HTTP/2", func(t *testing.T) {
+		server, protoMajor := newTLSServer(t, nil)
+		client := newTransportTestClient(t, server.URL, WithTLSConfig(&tls.Config{RootCAs: serverRoots(server), MinVersion: tls.VersionTLS12}))
+
+		if _, err := client.Balance.Get(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if protoMajor.Load() != 2 {
+			t.Errorf("expected HTTP/2, got HTTP/%d", protoMajor.Load())
+		}
+	})
+
+	t.Run("HTTP/2 can be disabled", func(t *testing.T) {
+		server, protoMajor := newTLSServer(t, nil)
+		client := newTransportTestClient(t, server.URL,
+			WithTLSConfig(&tls.Config{RootCAs: serverRoots(server), MinVersion: tls.VersionTLS12}),
+			WithHTTP2(false),
+		)
+
+		if _, err := client.Balance.Get(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if protoMajor.Load() != 1 {
+			t.Errorf("expected HTTP/1.1, got HTTP/%d", protoMajor.Load())
+		}
+	})
+
+	t.Run("mutual TLS", func(t *testing.T) {
+		server, _ := newTLSServer(t, func(config *tls.Config) {
+			config.ClientAuth = tls.RequireAnyClientCert
+		})
+
+		without := newTransportTestClient(t, server.URL, WithTLSConfig(&tls.Config{RootCAs: serverRoots(server), MinVersion: tls.VersionTLS12}))
+		if _, err := without.Balance.Get(ctx); err == nil {
+			t.Error("expected the handshake to fail without a client certificate")
+		}
+
+		with := newTransportTestClient(t, server.URL, WithTLSConfig(&tls.Config{
+			RootCAs:      serverRoots(server),
+			Certificates: []tls.Certificate{newClientCertificate(t)},
+			MinVersion:   tls.VersionTLS12,
+		}))
+		if _, err := with.Balance.Get(ctx); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestTransportTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	t.Run("request timeout", func(t *testing.T) {
+		client := newTransportTestClient(t, server.URL, WithRequestTimeout(50*time.Millisecond))
+		_, err := client.Balance.Get(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "Client.Timeout exceeded") {
+			t.Errorf("expected the request timeout to fire, got %v", err)
+		}
+	})
+
+	t.Run("call timeout covers retries", func(t *testing.T) {
+		client := newTransportTestClient(t, server.URL,
+			WithTimeout(100*time.Millisecond),
+			WithRequestTimeout(40*time.Millisecond),
+			WithRetryPolicy(&RetryPolicy{MaxRetries: 10, InitialDelay: time.Millisecond}),
+		)
+		start := time.Now()
+		_, err := client.Balance.Get(context.Background())
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the call deadline to fire, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected the call to stop after about 100ms, took %v", elapsed)
+		}
+	})
+}
+
+func TestTransportProxy(t *testing.T) {
+	var proxied atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Store(r.URL.String())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"amount":1,"currency":"eur"}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	client := newTransportTestClient(t, "http://api.verda.invalid/v1", WithProxy(proxyURL))
+
+	if _, err := client.Balance.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := proxied.Load().(string); got != "http://api.verda.invalid/v1/balance" {
+		t.Errorf("expected the request to go through the proxy, got %q", got)
+	}
+}
@@ -16,18 +16,36 @@ package verda
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"iter"
 	"net/url"
-	"strings"
 )
 
 type VolumeService struct {
 	client *Client
 }
 
+// List returns the volumes matching opts; nil opts lists all of them
+func (s *VolumeService) List(ctx context.Context, opts *VolumeListOptions) ([]Volume, error) {
+	return collect(s.All(ctx, opts))
+}
+
+// All iterates over the volumes matching opts, which are fetched together
+// in one unpaginated request
+func (s *VolumeService) All(ctx context.Context, opts *VolumeListOptions) iter.Seq2[Volume, error] {
+	o := VolumeListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	status := ""
+	if len(o.Statuses) == 1 {
+		status = o.Statuses[0]
+	}
+	return listSeq(ctx, func(ctx context.Context) ([]Volume, error) {
+		return s.ListVolumesByStatus(ctx, status)
+	}, o.matches)
+}
+
 func (s *VolumeService) ListVolumes(ctx context.Context) ([]Volume, error) {
 	return s.ListVolumesByStatus(ctx, "")
 }
@@ -62,31 +80,17 @@ func (s *VolumeService) CreateVolume(ctx context.Context, req VolumeCreateReques
 		return "", err
 	}
 	if req.LocationCode == "" {
-		req.LocationCode = LocationFIN03
+		req.LocationCode = s.client.defaultLocation()
 	}
 
 	return s.createVolumeWithPlainTextResponse(ctx, req)
 }
 
 func (s *VolumeService) createVolumeWithPlainTextResponse(ctx context.Context, req VolumeCreateRequest) (string, error) {
-	resp, err := s.client.makeRequest(ctx, http.MethodPost, "/volumes", req)
+	volumeID, _, err := postRequest[string](ctx, s.client, "/volumes", req)
 	if err != nil {
 		return "", err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", s.client.handleResponse(resp, nil)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	volumeID := strings.TrimSpace(string(body))
 	return volumeID, nil
 }
 
@@ -114,12 +118,11 @@ func (s *VolumeService) AttachVolume(ctx context.Context, volumeID string, req V
 	if err := req.Validate(); err != nil {
 		return err
 	}
-	payload := map[string]interface{}{
-		"id":          volumeID,
-		"action":      VolumeActionAttach,
-		"instance_id": req.InstanceID,
-	}
-	_, err := putRequestAllowEmptyResponse(ctx, s.client, "/volumes", payload)
+	_, err := s.actOne(ctx, VolumeActionRequest{
+		ID:         volumeID,
+		Action:     VolumeActionAttach,
+		InstanceID: req.InstanceID,
+	})
 	return err
 }
 
@@ -128,57 +131,108 @@ func (s *VolumeService) DetachVolume(ctx context.Context, volumeID string, req V
 	if err := req.Validate(); err != nil {
 		return err
 	}
-	payload := map[string]interface{}{
-		"id":          volumeID,
-		"action":      VolumeActionDetach,
-		"instance_id": req.InstanceID,
-	}
-	_, err := putRequestAllowEmptyResponse(ctx, s.client, "/volumes", payload)
+	_, err := s.actOne(ctx, VolumeActionRequest{
+		ID:         volumeID,
+		Action:     VolumeActionDetach,
+		InstanceID: req.InstanceID,
+	})
 	return err
 }
 
-// CloneVolume clones a volume and returns the new volume ID
-func (s *VolumeService) CloneVolume(ctx context.Context, volumeID string, req VolumeCloneRequest) (string, error) {
+// Action performs an action on any number of volumes, ActionBatchSize at a
+// time, and returns the result for each. If the action failed for some
+// volumes the error is a *BatchActionError listing them. A request that fails
+// as a whole stops the batch with its error, wrapped in a *BatchStoppedError
+// once earlier requests went through. With a key from
+// WithIdempotencyKey, each request uses the key followed by "-" and its chunk
+// index when there are several.
+//
+// Shared volumes (HDD_Shared and NVMe_Shared) can be attached to several
+// instances at once with InstanceIDs:
+//
+//	results, err := client.Volumes.Action(ctx, verda.VolumeBatchActionRequest{
+//		VolumeActionRequest: verda.VolumeActionRequest{
+//			Action:      verda.VolumeActionAttach,
+//			InstanceIDs: []string{instanceA, instanceB},
+//		},
+//		IDs: []string{sharedVolumeID},
+//	})
+func (s *VolumeService) Action(ctx context.Context, req VolumeBatchActionRequest) ([]VolumeActionResult, error) {
 	if err := req.Validate(); err != nil {
-		return "", err
+		return nil, err
 	}
-	actionReq := VolumeActionRequest{
-		ID:     volumeID,
-		Action: VolumeActionClone,
-		Name:   req.Name,
-		Type:   req.LocationCode, // Note: Python SDK uses 'type' field for location
+	if req.Action == VolumeActionClone && req.Type == "" {
+		req.Type = req.LocationCode
 	}
 
-	resp, err := s.client.makeRequest(ctx, http.MethodPut, "/volumes", actionReq)
-	if err != nil {
-		return "", err
+	chunks := chunkIDs(req.IDs)
+	var results []VolumeActionResult
+	done := 0
+	for i, ids := range chunks {
+		chunk := req
+		chunk.IDs = ids
+		chunkResults, err := s.act(chunkContext(ctx, i, len(chunks)), chunk, req.Action, ids)
+		if err != nil {
+			return results, batchStopped(ResourceKindVolume, req.Action, done, len(req.IDs), err)
+		}
+		results = append(results, chunkResults...)
+		done += len(ids)
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	return results, volumeBatchError(req.Action, len(req.IDs), results)
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", s.client.handleResponse(resp, nil)
+// actOne performs req on its single volume. The request body keeps the single
+// ID form the volume methods have always sent.
+func (s *VolumeService) actOne(ctx context.Context, req VolumeActionRequest) ([]VolumeActionResult, error) {
+	results, err := s.act(ctx, req, req.Action, []string{req.ID})
+	if err != nil {
+		return nil, err
 	}
+	return results, volumeBatchError(req.Action, 1, results)
+}
 
-	body, err := io.ReadAll(resp.Body)
+// act sends one PUT /volumes request acting on ids and returns the result for
+// each volume
+func (s *VolumeService) act(ctx context.Context, body any, action string, ids []string) ([]VolumeActionResult, error) {
+	resp, _, err := putRequest[volumeActionResponse](ctx, s.client, "/volumes", body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
+	return resp.resultsFor(action, ids), nil
+}
 
-	// API returns an array of volume IDs, get the first one
-	var volumeIDs []string
-	if err := json.Unmarshal(body, &volumeIDs); err != nil {
-		// If not an array, try as single string
-		newVolumeID := strings.TrimSpace(string(body))
-		return newVolumeID, nil
+// volumeBatchError returns a *BatchActionError for the failed results, if any
+func volumeBatchError(action string, total int, results []VolumeActionResult) error {
+	batchErr := &BatchActionError{Kind: ResourceKindVolume, Action: action, Total: total}
+	for _, result := range results {
+		if result.Failed() {
+			batchErr.Failures = append(batchErr.Failures, BatchFailure{ID: result.VolumeID, Message: result.message(), StatusCode: result.StatusCode})
+		}
 	}
-
-	if len(volumeIDs) > 0 {
-		return volumeIDs[0], nil
+	if len(batchErr.Failures) > 0 {
+		return batchErr
 	}
+	return nil
+}
 
-	return "", fmt.Errorf("no volume ID returned from clone operation")
+// CloneVolume clones a volume and returns the new volume ID
+func (s *VolumeService) CloneVolume(ctx context.Context, volumeID string, req VolumeCloneRequest) (string, error) {
+	if err := req.Validate(); err != nil {
+		return "", err
+	}
+	results, err := s.actOne(ctx, VolumeActionRequest{
+		ID:     volumeID,
+		Action: VolumeActionClone,
+		Name:   req.Name,
+		Type:   req.LocationCode, // Note: Python SDK uses 'type' field for location
+	})
+	if err != nil {
+		return "", err
+	}
+	if results[0].CloneID == "" {
+		return "", fmt.Errorf("no volume ID returned from clone operation")
+	}
+	return results[0].CloneID, nil
 }
 
 // ResizeVolume grows a volume - shrinking is not supported
@@ -186,12 +240,11 @@ func (s *VolumeService) ResizeVolume(ctx context.Context, volumeID string, req V
 	if err := req.Validate(); err != nil {
 		return err
 	}
-	actionReq := VolumeActionRequest{
+	_, err := s.actOne(ctx, VolumeActionRequest{
 		ID:     volumeID,
 		Action: VolumeActionResize,
 		Size:   req.Size,
-	}
-	_, err := putRequestAllowEmptyResponse(ctx, s.client, "/volumes", actionReq)
+	})
 	return err
 }
 
@@ -199,15 +252,24 @@ func (s *VolumeService) RenameVolume(ctx context.Context, volumeID string, req V
 	if err := req.Validate(); err != nil {
 		return err
 	}
-	actionReq := VolumeActionRequest{
+	_, err := s.actOne(ctx, VolumeActionRequest{
 		ID:     volumeID,
 		Action: VolumeActionRename,
 		Name:   req.Name,
-	}
-	_, err := putRequestAllowEmptyResponse(ctx, s.client, "/volumes", actionReq)
+	})
 	return err
 }
 
+// WaitForStatus polls the volume until it reaches status, e.g. VolumeStatusAttached
+// after AttachVolume. It fails fast with a *TerminalStatusError if the volume is
+// deleted or canceled instead.
+func (s *VolumeService) WaitForStatus(ctx context.Context, id, status string, opts *WaitOptions) (*Volume, error) {
+	return waitForResource(ctx, id, status, volumeTerminalStatuses, opts, s.GetVolume,
+		func(volume *Volume) (string, bool) {
+			return volume.Status, volume.Status == status
+		})
+}
+
 // AddTag adds a single key-value tag to a volume. Maximum 10 tags per volume.
 // Leave req.Value empty for a freeform tag.
 //
@@ -221,3 +283,14 @@ func (s *VolumeService) AddTag(ctx context.Context, volumeID string, req TagRequ
 func (s *VolumeService) DeleteTag(ctx context.Context, volumeID, key string) error {
 	return deleteResourceTag(ctx, s.client, "/volumes", volumeID, key)
 }
+
+// SetTags makes a volume carry exactly the desired tags; see InstanceService.SetTags
+func (s *VolumeService) SetTags(ctx context.Context, volumeID string, desired map[string]string) (*TagChanges, error) {
+	return setResourceTags(ctx, s.client, "/volumes", volumeID, desired, func(ctx context.Context) ([]Tag, error) {
+		volume, err := s.GetVolume(ctx, volumeID)
+		if err != nil {
+			return nil, err
+		}
+		return volume.Tags, nil
+	})
+}
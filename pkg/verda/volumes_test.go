@@ -17,7 +17,12 @@ package verda
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
@@ -678,3 +683,216 @@ func TestVolumeService_ErrorHandling(t *testing.T) {
 		}
 	})
 }
+
+func TestVolumeService_Action(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	var requests []VolumeBatchActionRequest
+	var keys []string
+	respond := func(status int, body string) {
+		requests = nil
+		keys = nil
+		mockServer.SetHandler(http.MethodPut, "/volumes", func(w http.ResponseWriter, r *http.Request) {
+			var req VolumeBatchActionRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+			requests = append(requests, req)
+			keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+			if body != "" {
+				w.Header().Set("Content-Type", "application/json")
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		})
+	}
+
+	batch := func(req VolumeActionRequest, ids ...string) VolumeBatchActionRequest {
+		return VolumeBatchActionRequest{VolumeActionRequest: req, IDs: ids}
+	}
+
+	t.Run("attach a shared volume to several instances", func(t *testing.T) {
+		respond(http.StatusAccepted, "")
+		results, err := client.Volumes.Action(ctx, batch(VolumeActionRequest{
+			Action:      VolumeActionAttach,
+			InstanceIDs: []string{"inst_1", "inst_2"},
+		}, "vol_shared"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(requests) != 1 || !slices.Equal(requests[0].InstanceIDs, []string{"inst_1", "inst_2"}) {
+			t.Errorf("expected instance_ids in the request, got %+v", requests)
+		}
+		if len(results) != 1 || results[0].VolumeID != "vol_shared" || results[0].Status != ActionStatusSuccess {
+			t.Errorf("expected a successful result for vol_shared, got %+v", results)
+		}
+	})
+
+	t.Run("clone IDs are matched to volumes", func(t *testing.T) {
+		respond(http.StatusAccepted, `["vol_c1","vol_c2"]`)
+		results, err := client.Volumes.Action(ctx, batch(VolumeActionRequest{Action: VolumeActionClone, Name: "backup"}, "vol_1", "vol_2"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(results) != 2 || results[0].CloneID != "vol_c1" || results[1].CloneID != "vol_c2" {
+			t.Errorf("unexpected clone results: %+v", results)
+		}
+	})
+
+	t.Run("clones are placed like CloneVolume places them", func(t *testing.T) {
+		respond(http.StatusAccepted, `["vol_c1"]`)
+		if _, err := client.Volumes.Action(ctx, batch(VolumeActionRequest{Action: VolumeActionClone, LocationCode: LocationFIN03}, "vol_1")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(requests) != 1 || requests[0].Type != LocationFIN03 {
+			t.Errorf("expected the location in type, got %+v", requests)
+		}
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		respond(http.StatusMultiStatus, `[
+			{"action": "delete", "volumeId": "vol_1", "status": "success"},
+			{"action": "delete", "volumeId": "vol_2", "status": "error", "error": "volume is attached", "statusCode": 409}
+		]`)
+		results, err := client.Volumes.Action(ctx, batch(VolumeActionRequest{Action: VolumeActionDelete, IsPermanent: true}, "vol_1", "vol_2"))
+		var batchErr *BatchActionError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("expected *BatchActionError, got %T: %v", err, err)
+		}
+		want := "delete failed for 1 of 2 volumes: vol_2: volume is attached (409)"
+		if err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+		if len(results) != 2 || !results[1].Failed() {
+			t.Errorf("expected both results, got %+v", results)
+		}
+		if !requests[0].IsPermanent {
+			t.Error("expected is_permanent in the request")
+		}
+	})
+
+	t.Run("long ID lists are chunked", func(t *testing.T) {
+		respond(http.StatusAccepted, "")
+		ids := make([]string, ActionBatchSize+5)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("vol_%d", i)
+		}
+		results, err := client.Volumes.Action(ctx, batch(VolumeActionRequest{Action: VolumeActionResize, Size: 200}, ids...))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(requests) != 2 || len(requests[1].IDs) != 5 || len(results) != len(ids) {
+			t.Errorf("expected 2 requests and %d results, got %d and %d", len(ids), len(requests), len(results))
+		}
+	})
+
+	t.Run("each chunk gets its own idempotency key", func(t *testing.T) {
+		respond(http.StatusAccepted, "")
+		ids := make([]string, ActionBatchSize+1)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("vol_%d", i)
+		}
+		if _, err := client.Volumes.Action(WithIdempotencyKey(ctx, "detach-1"), batch(VolumeActionRequest{Action: VolumeActionDetach}, ids...)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []string{"detach-1-0", "detach-1-1"}; !slices.Equal(keys, want) {
+			t.Errorf("expected keys %v, got %v", want, keys)
+		}
+	})
+
+	t.Run("a failed later request reports how far the batch got", func(t *testing.T) {
+		calls := 0
+		mockServer.SetHandler(http.MethodPut, "/volumes", func(w http.ResponseWriter, _ *http.Request) {
+			if calls++; calls == 2 {
+				testutil.ErrorResponse(w, http.StatusServiceUnavailable, "try again later")
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		})
+		ids := make([]string, ActionBatchSize+1)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("vol_%d", i)
+		}
+
+		results, err := client.Volumes.Action(ctx, batch(VolumeActionRequest{Action: VolumeActionDetach}, ids...))
+		var stopped *BatchStoppedError
+		if !errors.As(err, &stopped) || stopped.Done != ActionBatchSize || stopped.Total != len(ids) || stopped.Kind != ResourceKindVolume {
+			t.Fatalf("expected a *BatchStoppedError after the first request, got %v", err)
+		}
+		if len(results) != ActionBatchSize {
+			t.Errorf("expected the results of the first request, got %d", len(results))
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		respond(http.StatusAccepted, "")
+		for name, req := range map[string]VolumeBatchActionRequest{
+			"unknown action":         batch(VolumeActionRequest{Action: "explode"}, "vol_1"),
+			"no volumes":             batch(VolumeActionRequest{Action: VolumeActionDetach, ID: "vol_1"}),
+			"attach without targets": batch(VolumeActionRequest{Action: VolumeActionAttach}, "vol_1"),
+			"rename without name":    batch(VolumeActionRequest{Action: VolumeActionRename}, "vol_1"),
+			"resize without size":    batch(VolumeActionRequest{Action: VolumeActionResize}, "vol_1"),
+		} {
+			if _, err := client.Volumes.Action(ctx, req); err == nil {
+				t.Errorf("%s: expected a validation error", name)
+			}
+		}
+		if len(requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(requests))
+		}
+	})
+}
+
+func TestVolumeService_SingleVolumeRequests(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	var body string
+	mockServer.SetHandler(http.MethodPut, "/volumes", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`["vol_clone"]`))
+	})
+
+	tests := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{"attach", func() error {
+			return client.Volumes.AttachVolume(ctx, "vol_1", VolumeAttachRequest{InstanceID: "inst_1"})
+		}, `{"id":"vol_1","action":"attach","instance_id":"inst_1"}`},
+		{"detach", func() error {
+			return client.Volumes.DetachVolume(ctx, "vol_1", VolumeDetachRequest{InstanceID: "inst_1"})
+		}, `{"id":"vol_1","action":"detach","instance_id":"inst_1"}`},
+		{"rename", func() error {
+			return client.Volumes.RenameVolume(ctx, "vol_1", VolumeRenameRequest{Name: "data"})
+		}, `{"id":"vol_1","action":"rename","name":"data"}`},
+		{"resize", func() error {
+			return client.Volumes.ResizeVolume(ctx, "vol_1", VolumeResizeRequest{Size: 200})
+		}, `{"id":"vol_1","action":"resize","size":200}`},
+		{"clone", func() error {
+			_, err := client.Volumes.CloneVolume(ctx, "vol_1", VolumeCloneRequest{Name: "copy", LocationCode: LocationFIN03})
+			return err
+		}, `{"id":"vol_1","action":"clone","name":"copy","type":"FIN-03"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.TrimSpace(body) != tt.want {
+				t.Errorf("expected body %s, got %s", tt.want, body)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package verda
 
 import (
+	"encoding/json"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -48,17 +49,94 @@ type VolumeDetachRequest struct {
 	InstanceID string `json:"instance_id"`
 }
 
-// VolumeActionRequest represents an action to perform on volumes
+// VolumeActionRequest represents an action to perform on a volume
 type VolumeActionRequest struct {
-	ID           string   `json:"id"`
-	Action       string   `json:"action"`
-	Name         string   `json:"name,omitempty"`
-	Type         string   `json:"type,omitempty"`
-	Size         int      `json:"size,omitempty"`
-	InstanceID   string   `json:"instance_id,omitempty"`
-	InstanceIDs  []string `json:"instance_ids,omitempty"`
-	IsPermanent  bool     `json:"is_permanent,omitempty"`
-	LocationCode string   `json:"location_code,omitempty"`
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	// Name is the new name of a renamed volume and the name of a clone
+	Name string `json:"name,omitempty"`
+	// Type is the field the API reads the location code of clones from
+	Type string `json:"type,omitempty"`
+	// Size is the new size of a resized volume in GB
+	Size int `json:"size,omitempty"`
+	// InstanceID is the instance to attach the volume to or detach it from
+	InstanceID string `json:"instance_id,omitempty"`
+	// InstanceIDs attaches an HDD_Shared or NVMe_Shared volume to several
+	// instances at once
+	InstanceIDs []string `json:"instance_ids,omitempty"`
+	// IsPermanent deletes a volume for good instead of moving it to the trash
+	IsPermanent bool `json:"is_permanent,omitempty"`
+	// LocationCode is the location of clones. VolumeService.Action copies it
+	// into Type when Type is empty.
+	LocationCode string `json:"location_code,omitempty"`
+}
+
+// VolumeBatchActionRequest is an action on several volumes for
+// VolumeService.Action. The embedded request carries the action and its
+// fields; its ID is ignored in favour of IDs.
+type VolumeBatchActionRequest struct {
+	VolumeActionRequest
+	IDs []string `json:"id"`
+}
+
+// VolumeActionResult represents the per-volume outcome of a volume action
+type VolumeActionResult struct {
+	Action     string `json:"action"`
+	VolumeID   string `json:"volumeId"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	// CloneID is the ID of the volume created by a clone action
+	CloneID string `json:"cloneId,omitempty"`
+}
+
+// Failed reports whether the action failed for the volume
+func (r VolumeActionResult) Failed() bool {
+	return r.Status == ActionStatusError || r.Error != ""
+}
+
+func (r VolumeActionResult) message() string {
+	if r.Error != "" {
+		return r.Error
+	}
+	return r.Status
+}
+
+// volumeActionResponse is the response of PUT /volumes: per-volume results,
+// or, when the action succeeded for every volume, nothing or the IDs of
+// clones
+type volumeActionResponse struct {
+	results []VolumeActionResult
+	ids     idList
+}
+
+func (r *volumeActionResponse) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &r.results); err == nil {
+		return nil
+	}
+	r.results = nil
+	return json.Unmarshal(data, &r.ids)
+}
+
+func (r *volumeActionResponse) UnmarshalText(text []byte) error {
+	return r.ids.UnmarshalText(text)
+}
+
+// resultsFor returns the results of action on ids, making up successful ones
+// when the API answered without them. Clone IDs are matched to the cloned
+// volumes in order.
+func (r *volumeActionResponse) resultsFor(action string, ids []string) []VolumeActionResult {
+	if len(r.results) > 0 {
+		return r.results
+	}
+	results := make([]VolumeActionResult, len(ids))
+	for i, id := range ids {
+		results[i] = VolumeActionResult{Action: action, VolumeID: id, Status: ActionStatusSuccess}
+		if action == VolumeActionClone && i < len(r.ids) {
+			results[i].CloneID = r.ids[i]
+		}
+	}
+	return results
 }
 
 // VolumeCloneRequest represents a request to clone a volume
@@ -198,6 +276,20 @@ func (r VolumeCreateRequest) Validate() error {
 	)
 }
 
+// Validate validates the VolumeBatchActionRequest fields
+func (r VolumeBatchActionRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Action, validation.Required,
+			validation.In(VolumeActionAttach, VolumeActionDetach, VolumeActionRename,
+				VolumeActionResize, VolumeActionDelete, VolumeActionClone)),
+		validation.Field(&r.IDs, validation.Required),
+		validation.Field(&r.InstanceID,
+			validation.When(r.Action == VolumeActionAttach && len(r.InstanceIDs) == 0, validation.Required)),
+		validation.Field(&r.Name, validation.When(r.Action == VolumeActionRename, validation.Required)),
+		validation.Field(&r.Size, validation.When(r.Action == VolumeActionResize, validation.Required, validation.Min(1))),
+	)
+}
+
 // Validate validates the VolumeCloneRequest fields
 func (r VolumeCloneRequest) Validate() error {
 	return validation.ValidateStruct(&r,
@@ -232,3 +324,27 @@ func (r VolumeDetachRequest) Validate() error {
 		validation.Field(&r.InstanceID, validation.Required),
 	)
 }
+
+// VolumeListOptions filters VolumeService.List and All. Zero fields do not
+// filter.
+type VolumeListOptions struct {
+	// Statuses keeps volumes in any of these statuses; a single status is
+	// filtered by the API
+	Statuses []string
+	Location string
+	// Type is the volume type, e.g. VolumeTypeNVMe
+	Type          string
+	Tags          TagSelector
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	NamePrefix    string
+}
+
+func (o *VolumeListOptions) matches(v *Volume) bool {
+	return matchesStatus(v.Status, o.Statuses) &&
+		(o.Location == "" || v.Location == o.Location) &&
+		(o.Type == "" || v.Type == o.Type) &&
+		matchesTags(o.Tags, v.Tags) &&
+		createdBetween(v.CreatedAt, o.CreatedAfter, o.CreatedBefore) &&
+		matchesPrefix(v.Name, o.NamePrefix)
+}
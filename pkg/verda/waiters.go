@@ -0,0 +1,173 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Waiter defaults
+const (
+	DefaultWaitPollInterval      = 5 * time.Second
+	DefaultWaitMaxPollInterval   = 30 * time.Second
+	DefaultWaitBackoffMultiplier = 1.5
+)
+
+// WaitOptions configures how a waiter polls a resource. A nil *WaitOptions uses
+// the defaults. Cancel ctx (or give it a deadline) to bound the total wait.
+type WaitOptions struct {
+	// PollInterval is the delay before the second poll. Defaults to 5s.
+	PollInterval time.Duration
+	// MaxPollInterval caps the delay between polls as it backs off. Defaults to 30s.
+	MaxPollInterval time.Duration
+	// BackoffMultiplier grows the delay after every poll. Values below 1 are
+	// treated as 1 (fixed interval). Defaults to 1.5.
+	BackoffMultiplier float64
+	// Timeout bounds the total wait on top of any ctx deadline. Zero means no limit.
+	Timeout time.Duration
+	// OnProgress, when set, is called after every poll.
+	OnProgress func(WaitProgress)
+}
+
+// WaitProgress describes a single poll made by a waiter
+type WaitProgress struct {
+	ResourceID string
+	Attempt    int
+	Status     string
+	Elapsed    time.Duration
+	// NextPoll is the delay until the next poll, zero once the wait is over
+	NextPoll time.Duration
+}
+
+// TerminalStatusError is returned by a waiter when the resource reaches a
+// status it cannot leave on its own, such as StatusError or StatusNoCapacity.
+type TerminalStatusError struct {
+	ResourceID string
+	Status     string
+	Wanted     string
+}
+
+func (e *TerminalStatusError) Error() string {
+	return fmt.Sprintf("resource %s reached terminal status %q while waiting for %q", e.ResourceID, e.Status, e.Wanted)
+}
+
+// Is matches ErrNoCapacity when the resource reached StatusNoCapacity
+func (e *TerminalStatusError) Is(target error) bool {
+	return target == ErrNoCapacity && e.Status == StatusNoCapacity
+}
+
+// Terminal statuses shared by instances and clusters
+var instanceTerminalStatuses = []string{StatusError, StatusNoCapacity, StatusDiscontinued}
+
+// Volumes cannot come back from these statuses
+var volumeTerminalStatuses = []string{VolumeStatusDeleted, VolumeStatusCanceled}
+
+// waitCheck inspects a freshly fetched resource and reports its status and
+// whether the wait is complete.
+type waitCheck[T any] func(resource T) (status string, done bool)
+
+// waitForResource is the polling engine shared by all resource waiters.
+// It fetches the resource, fails fast on a terminal status and otherwise
+// sleeps with backoff until check reports done or ctx is cancelled.
+func waitForResource[T any](ctx context.Context, id, wanted string, terminal []string, opts *WaitOptions,
+	fetch func(ctx context.Context, id string) (T, error), check waitCheck[T]) (T, error) {
+	var zero T
+
+	if id == "" {
+		return zero, fmt.Errorf("resource ID is required")
+	}
+	if wanted == "" {
+		return zero, fmt.Errorf("target status is required")
+	}
+
+	o := resolveWaitOptions(opts)
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	interval := o.PollInterval
+
+	for attempt := 1; ; attempt++ {
+		resource, err := fetch(ctx, id)
+		if err != nil {
+			return zero, err
+		}
+
+		status, done := check(resource)
+		failed := !done && slices.Contains(terminal, status)
+
+		progress := WaitProgress{
+			ResourceID: id,
+			Attempt:    attempt,
+			Status:     status,
+			Elapsed:    time.Since(start),
+		}
+		if !done && !failed {
+			progress.NextPoll = interval
+		}
+		if o.OnProgress != nil {
+			o.OnProgress(progress)
+		}
+
+		if done {
+			return resource, nil
+		}
+		if failed {
+			return resource, &TerminalStatusError{ResourceID: id, Status: status, Wanted: wanted}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resource, fmt.Errorf("waiting for %s to reach %q (last status %q): %w", id, wanted, status, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * o.BackoffMultiplier)
+		if interval > o.MaxPollInterval {
+			interval = o.MaxPollInterval
+		}
+	}
+}
+
+func resolveWaitOptions(opts *WaitOptions) WaitOptions {
+	var o WaitOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultWaitPollInterval
+	}
+	if o.MaxPollInterval <= 0 {
+		o.MaxPollInterval = DefaultWaitMaxPollInterval
+	}
+	if o.MaxPollInterval < o.PollInterval {
+		o.MaxPollInterval = o.PollInterval
+	}
+	if o.BackoffMultiplier == 0 {
+		o.BackoffMultiplier = DefaultWaitBackoffMultiplier
+	}
+	if o.BackoffMultiplier < 1 {
+		o.BackoffMultiplier = 1
+	}
+	return o
+}
@@ -0,0 +1,310 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+// fastWait polls quickly so tests don't sit on the production defaults
+var fastWait = &WaitOptions{PollInterval: time.Millisecond, MaxPollInterval: 5 * time.Millisecond}
+
+// statusSequence serves resource JSON with each status in turn, repeating the last one
+func statusSequence(t *testing.T, build func(status string) any, statuses ...string) (http.HandlerFunc, func() int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	calls := 0
+
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		idx := calls
+		calls++
+		mu.Unlock()
+
+		if idx >= len(statuses) {
+			idx = len(statuses) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(build(statuses[idx]))
+	}
+
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+
+	return handler, count
+}
+
+func TestInstanceService_WaitForStatus(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	buildInstance := func(id string) func(string) any {
+		return func(status string) any {
+			return map[string]any{"id": id, "status": status}
+		}
+	}
+
+	t.Run("returns once the status is reached", func(t *testing.T) {
+		handler, calls := statusSequence(t, buildInstance("inst_wait"), StatusOrdered, StatusProvisioning, StatusRunning)
+		mockServer.SetHandler(http.MethodGet, "/instances/inst_wait", handler)
+
+		var progress []WaitProgress
+		opts := *fastWait
+		opts.OnProgress = func(p WaitProgress) { progress = append(progress, p) }
+
+		instance, err := client.Instances.WaitForStatus(ctx, "inst_wait", StatusRunning, &opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if instance.Status != StatusRunning {
+			t.Errorf("expected status %s, got %s", StatusRunning, instance.Status)
+		}
+		if calls() != 3 {
+			t.Errorf("expected 3 polls, got %d", calls())
+		}
+		if len(progress) != 3 {
+			t.Fatalf("expected 3 progress callbacks, got %d", len(progress))
+		}
+		if progress[0].Status != StatusOrdered || progress[0].Attempt != 1 {
+			t.Errorf("unexpected first progress: %+v", progress[0])
+		}
+		if progress[2].NextPoll != 0 {
+			t.Errorf("expected no next poll after completion, got %v", progress[2].NextPoll)
+		}
+	})
+
+	for _, terminal := range []string{StatusError, StatusNoCapacity, StatusDiscontinued} {
+		t.Run("fails fast on "+terminal, func(t *testing.T) {
+			path := "/instances/inst_" + terminal
+			handler, calls := statusSequence(t, buildInstance("inst_"+terminal), StatusProvisioning, terminal)
+			mockServer.SetHandler(http.MethodGet, path, handler)
+
+			_, err := client.Instances.WaitForStatus(ctx, "inst_"+terminal, StatusRunning, fastWait)
+
+			var termErr *TerminalStatusError
+			if !errors.As(err, &termErr) {
+				t.Fatalf("expected *TerminalStatusError, got %T: %v", err, err)
+			}
+			if termErr.Status != terminal {
+				t.Errorf("expected terminal status %s, got %s", terminal, termErr.Status)
+			}
+			if calls() != 2 {
+				t.Errorf("expected 2 polls, got %d", calls())
+			}
+		})
+	}
+
+	t.Run("waiting for a terminal status succeeds", func(t *testing.T) {
+		handler, _ := statusSequence(t, buildInstance("inst_disc"), StatusRunning, StatusDiscontinued)
+		mockServer.SetHandler(http.MethodGet, "/instances/inst_disc", handler)
+
+		if _, err := client.Instances.WaitForStatus(ctx, "inst_disc", StatusDiscontinued, fastWait); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		handler, _ := statusSequence(t, buildInstance("inst_slow"), StatusProvisioning)
+		mockServer.SetHandler(http.MethodGet, "/instances/inst_slow", handler)
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+
+		_, err := client.Instances.WaitForStatus(ctx, "inst_slow", StatusRunning, fastWait)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("timeout option bounds the wait", func(t *testing.T) {
+		handler, _ := statusSequence(t, buildInstance("inst_timeout"), StatusProvisioning)
+		mockServer.SetHandler(http.MethodGet, "/instances/inst_timeout", handler)
+
+		opts := *fastWait
+		opts.Timeout = 20 * time.Millisecond
+
+		_, err := client.Instances.WaitForStatus(ctx, "inst_timeout", StatusRunning, &opts)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("fetch errors are returned", func(t *testing.T) {
+		mockServer.SetHandler(http.MethodGet, "/instances/inst_gone", func(w http.ResponseWriter, _ *http.Request) {
+			testutil.ErrorResponse(w, http.StatusNotFound, "instance not found")
+		})
+
+		_, err := client.Instances.WaitForStatus(ctx, "inst_gone", StatusRunning, fastWait)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("expected 404 *APIError, got %v", err)
+		}
+	})
+
+	t.Run("empty ID is rejected", func(t *testing.T) {
+		if _, err := client.Instances.WaitForStatus(ctx, "", StatusRunning, fastWait); err == nil {
+			t.Error("expected error for an empty instance ID, got nil")
+		}
+	})
+}
+
+func TestVolumeService_WaitForStatus(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	buildVolume := func(status string) any {
+		return map[string]any{"id": "vol_wait", "status": status}
+	}
+
+	t.Run("waits for attached", func(t *testing.T) {
+		handler, _ := statusSequence(t, buildVolume, VolumeStatusDetached, VolumeStatusAttaching, VolumeStatusAttached)
+		mockServer.SetHandler(http.MethodGet, "/volumes/vol_wait", handler)
+
+		volume, err := client.Volumes.WaitForStatus(ctx, "vol_wait", VolumeStatusAttached, fastWait)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if volume.Status != VolumeStatusAttached {
+			t.Errorf("expected status %s, got %s", VolumeStatusAttached, volume.Status)
+		}
+	})
+
+	t.Run("fails fast when deleted", func(t *testing.T) {
+		handler, _ := statusSequence(t, buildVolume, VolumeStatusDeleting, VolumeStatusDeleted)
+		mockServer.SetHandler(http.MethodGet, "/volumes/vol_wait", handler)
+
+		_, err := client.Volumes.WaitForStatus(ctx, "vol_wait", VolumeStatusAttached, fastWait)
+		var termErr *TerminalStatusError
+		if !errors.As(err, &termErr) {
+			t.Fatalf("expected *TerminalStatusError, got %T: %v", err, err)
+		}
+	})
+}
+
+func TestClusterService_WaitForStatus(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+
+	client := NewTestClient(mockServer)
+	ctx := context.Background()
+
+	// Each poll returns the cluster status followed by the status of its two nodes
+	clusterStates := func(states ...[3]string) http.HandlerFunc {
+		var mu sync.Mutex
+		calls := 0
+		return func(w http.ResponseWriter, _ *http.Request) {
+			mu.Lock()
+			idx := min(calls, len(states)-1)
+			calls++
+			mu.Unlock()
+
+			state := states[idx]
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":     "cluster_wait",
+				"status": state[0],
+				"worker_nodes": []map[string]string{
+					{"id": "node_1", "status": state[1]},
+					{"id": "node_2", "status": state[2]},
+				},
+			})
+		}
+	}
+
+	t.Run("waits for every worker node", func(t *testing.T) {
+		mockServer.SetHandler(http.MethodGet, "/clusters/cluster_wait", clusterStates(
+			[3]string{StatusProvisioning, StatusProvisioning, StatusProvisioning},
+			[3]string{StatusRunning, StatusRunning, StatusProvisioning},
+			[3]string{StatusRunning, StatusRunning, StatusRunning},
+		))
+
+		var seen []string
+		opts := *fastWait
+		opts.OnProgress = func(p WaitProgress) { seen = append(seen, p.Status) }
+
+		cluster, err := client.Clusters.WaitForStatus(ctx, "cluster_wait", StatusRunning, &opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(cluster.WorkerNodes) != 2 {
+			t.Errorf("expected 2 worker nodes, got %d", len(cluster.WorkerNodes))
+		}
+		if len(seen) != 3 || seen[1] != StatusProvisioning {
+			t.Errorf("expected the unsettled node status to be reported, got %v", seen)
+		}
+	})
+
+	t.Run("fails fast when a node errors", func(t *testing.T) {
+		mockServer.SetHandler(http.MethodGet, "/clusters/cluster_wait", clusterStates(
+			[3]string{StatusRunning, StatusRunning, StatusError},
+		))
+
+		_, err := client.Clusters.WaitForStatus(ctx, "cluster_wait", StatusRunning, fastWait)
+		var termErr *TerminalStatusError
+		if !errors.As(err, &termErr) {
+			t.Fatalf("expected *TerminalStatusError, got %T: %v", err, err)
+		}
+		if termErr.Status != StatusError {
+			t.Errorf("expected terminal status %s, got %s", StatusError, termErr.Status)
+		}
+	})
+}
+
+func TestResolveWaitOptions(t *testing.T) {
+	t.Run("nil uses defaults", func(t *testing.T) {
+		o := resolveWaitOptions(nil)
+		if o.PollInterval != DefaultWaitPollInterval {
+			t.Errorf("expected poll interval %v, got %v", DefaultWaitPollInterval, o.PollInterval)
+		}
+		if o.MaxPollInterval != DefaultWaitMaxPollInterval {
+			t.Errorf("expected max poll interval %v, got %v", DefaultWaitMaxPollInterval, o.MaxPollInterval)
+		}
+		if o.BackoffMultiplier != DefaultWaitBackoffMultiplier {
+			t.Errorf("expected multiplier %v, got %v", DefaultWaitBackoffMultiplier, o.BackoffMultiplier)
+		}
+	})
+
+	t.Run("max interval never below poll interval", func(t *testing.T) {
+		o := resolveWaitOptions(&WaitOptions{PollInterval: time.Minute, MaxPollInterval: time.Second})
+		if o.MaxPollInterval != time.Minute {
+			t.Errorf("expected max poll interval raised to 1m, got %v", o.MaxPollInterval)
+		}
+	})
+
+	t.Run("multiplier below one means fixed interval", func(t *testing.T) {
+		o := resolveWaitOptions(&WaitOptions{BackoffMultiplier: 0.5})
+		if o.BackoffMultiplier != 1 {
+			t.Errorf("expected multiplier 1, got %v", o.BackoffMultiplier)
+		}
+	})
+}
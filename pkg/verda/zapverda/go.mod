@@ -0,0 +1,18 @@
+module github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/zapverda
+
+go 1.25.0
+
+require (
+	github.com/verda-cloud/verdacloud-sdk-go v1.5.0
+	go.uber.org/zap v1.28.0
+)
+
+require (
+	github.com/go-ozzo/ozzo-validation/v4 v4.3.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+)
+
+// Builds against this checkout of the SDK during development. Users of this
+// module get the SDK version required above; make release sets it to the
+// release being tagged.
+replace github.com/verda-cloud/verdacloud-sdk-go => ../../..
@@ -0,0 +1,135 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zapverda sends the SDK's structured logs to a zap logger.
+//
+//	client, _ := verda.NewClient(
+//	    verda.WithClientID(id),
+//	    verda.WithClientSecret(secret),
+//	    verda.WithLogger(zapverda.NewLogger(zapLogger)),
+//	)
+package zapverda
+
+import (
+	"context"
+	"log/slog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// NewLogger returns a verda.Logger that writes structured records to logger
+func NewLogger(logger *zap.Logger) *verda.SlogLogger {
+	return verda.NewSlogLoggerFrom(slog.New(NewHandler(logger)))
+}
+
+// NewHandler returns a slog.Handler that writes to logger. Groups are
+// flattened into dotted field names.
+func NewHandler(logger *zap.Logger) slog.Handler {
+	return &handler{logger: logger}
+}
+
+type handler struct {
+	logger *zap.Logger
+	group  string
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Core().Enabled(zapLevel(level))
+}
+
+func (h *handler) Handle(_ context.Context, record slog.Record) error {
+	ce := h.logger.Check(zapLevel(record.Level), record.Message)
+	if ce == nil {
+		return nil
+	}
+
+	fields := make([]zap.Field, 0, record.NumAttrs())
+	record.Attrs(func(a slog.Attr) bool {
+		fields = appendFields(fields, h.group, a)
+		return true
+	})
+	ce.Write(fields...)
+	return nil
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]zap.Field, 0, len(attrs))
+	for _, a := range attrs {
+		fields = appendFields(fields, h.group, a)
+	}
+	return &handler{logger: h.logger.With(fields...), group: h.group}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{logger: h.logger, group: joinKey(h.group, name)}
+}
+
+func zapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
+
+func appendFields(fields []zap.Field, prefix string, a slog.Attr) []zap.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	key := joinKey(prefix, a.Key)
+	v := a.Value
+	switch v.Kind() {
+	case slog.KindGroup:
+		for _, ga := range v.Group() {
+			fields = appendFields(fields, key, ga)
+		}
+		return fields
+	case slog.KindString:
+		return append(fields, zap.String(key, v.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(key, v.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(key, v.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(key, v.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(key, v.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(key, v.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(key, v.Time()))
+	default:
+		if err, ok := v.Any().(error); ok {
+			return append(fields, zap.NamedError(key, err))
+		}
+		return append(fields, zap.Any(key, v.Any()))
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
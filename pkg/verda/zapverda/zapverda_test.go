@@ -0,0 +1,75 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zapverda
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func TestHandler(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := slog.New(NewHandler(zap.New(core)))
+
+	logger.Debug("hidden")
+	logger.With("service", "verda").WithGroup("http").Info("request",
+		slog.String("method", "GET"), slog.Int("status", 200))
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected debug to be filtered, got %d entries", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if entries[0].Message != "request" || fields["service"] != "verda" ||
+		fields["http.method"] != "GET" || fields["http.status"] != int64(200) {
+		t.Errorf("unexpected entry %q with %v", entries[0].Message, fields)
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+	config := testutil.NewTestClientConfig(mockServer)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	client, err := verda.NewClient(
+		verda.WithBaseURL(config.BaseURL),
+		verda.WithClientID(config.ClientID),
+		verda.WithClientSecret(config.ClientSecret),
+		verda.WithLogger(NewLogger(zap.New(core))),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Balance.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, entry := range logs.All() {
+		fields := entry.ContextMap()
+		if _, ok := fields[verda.LogKeyStatus]; ok && fields[verda.LogKeyPath] != nil {
+			return
+		}
+	}
+	t.Errorf("expected a response record with status and path, got %v", logs.All())
+}
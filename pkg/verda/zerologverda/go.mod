@@ -0,0 +1,20 @@
+module github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/zerologverda
+
+go 1.25.0
+
+require (
+	github.com/rs/zerolog v1.35.1
+	github.com/verda-cloud/verdacloud-sdk-go v1.5.0
+)
+
+require (
+	github.com/go-ozzo/ozzo-validation/v4 v4.3.0 // indirect
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
+
+// Builds against this checkout of the SDK during development. Users of this
+// module get the SDK version required above; make release sets it to the
+// release being tagged.
+replace github.com/verda-cloud/verdacloud-sdk-go => ../../..
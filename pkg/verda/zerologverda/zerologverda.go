@@ -0,0 +1,151 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zerologverda sends the SDK's structured logs to a zerolog logger.
+//
+//	client, _ := verda.NewClient(
+//	    verda.WithClientID(id),
+//	    verda.WithClientSecret(secret),
+//	    verda.WithLogger(zerologverda.NewLogger(log.Logger)),
+//	)
+package zerologverda
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// NewLogger returns a verda.Logger that writes structured records to logger
+func NewLogger(logger zerolog.Logger) *verda.SlogLogger {
+	return verda.NewSlogLoggerFrom(slog.New(NewHandler(logger)))
+}
+
+// NewHandler returns a slog.Handler that writes to logger. Groups are
+// flattened into dotted field names.
+func NewHandler(logger zerolog.Logger) slog.Handler {
+	return &handler{logger: logger}
+}
+
+type handler struct {
+	logger zerolog.Logger
+	group  string
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	l := zerologLevel(level)
+	return l >= h.logger.GetLevel() && l >= zerolog.GlobalLevel()
+}
+
+func (h *handler) Handle(_ context.Context, record slog.Record) error {
+	event := h.logger.WithLevel(zerologLevel(record.Level))
+	if event == nil {
+		return nil
+	}
+
+	record.Attrs(func(a slog.Attr) bool {
+		addField(event, h.group, a)
+		return true
+	})
+	event.Msg(record.Message)
+	return nil
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(map[string]any, len(attrs))
+	for _, a := range attrs {
+		collect(fields, h.group, a)
+	}
+	return &handler{logger: h.logger.With().Fields(fields).Logger(), group: h.group}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{logger: h.logger, group: joinKey(h.group, name)}
+}
+
+func zerologLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zerolog.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zerolog.WarnLevel
+	case level >= slog.LevelInfo:
+		return zerolog.InfoLevel
+	default:
+		return zerolog.DebugLevel
+	}
+}
+
+func addField(event *zerolog.Event, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	key := joinKey(prefix, a.Key)
+	v := a.Value
+	switch v.Kind() {
+	case slog.KindGroup:
+		for _, ga := range v.Group() {
+			addField(event, key, ga)
+		}
+	case slog.KindString:
+		event.Str(key, v.String())
+	case slog.KindInt64:
+		event.Int64(key, v.Int64())
+	case slog.KindUint64:
+		event.Uint64(key, v.Uint64())
+	case slog.KindFloat64:
+		event.Float64(key, v.Float64())
+	case slog.KindBool:
+		event.Bool(key, v.Bool())
+	case slog.KindDuration:
+		event.Dur(key, v.Duration())
+	case slog.KindTime:
+		event.Time(key, v.Time())
+	default:
+		if err, ok := v.Any().(error); ok {
+			event.AnErr(key, err)
+			return
+		}
+		event.Interface(key, v.Any())
+	}
+}
+
+// collect flattens a into fields for use as logger context
+func collect(fields map[string]any, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	key := joinKey(prefix, a.Key)
+	if a.Value.Kind() == slog.KindGroup {
+		for _, ga := range a.Value.Group() {
+			collect(fields, key, ga)
+		}
+		return
+	}
+	fields[key] = a.Value.Any()
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
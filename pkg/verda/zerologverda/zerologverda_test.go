@@ -0,0 +1,75 @@
+// Copyright 2026 Verda Cloud Oy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zerologverda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda/testutil"
+)
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(zerolog.New(&buf).Level(zerolog.InfoLevel)))
+
+	logger.Debug("hidden")
+	logger.With("service", "verda").WithGroup("http").Info("request",
+		slog.String("method", "GET"), slog.Int("status", 200))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected debug to be filtered, got %q", buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry["message"] != "request" || entry["level"] != "info" || entry["service"] != "verda" ||
+		entry["http.method"] != "GET" || entry["http.status"] != float64(200) {
+		t.Errorf("unexpected entry %v", entry)
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	mockServer := testutil.NewMockServer()
+	defer mockServer.Close()
+	config := testutil.NewTestClientConfig(mockServer)
+
+	var buf bytes.Buffer
+	client, err := verda.NewClient(
+		verda.WithBaseURL(config.BaseURL),
+		verda.WithClientID(config.ClientID),
+		verda.WithClientSecret(config.ClientSecret),
+		verda.WithLogger(NewLogger(zerolog.New(&buf))),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Balance.Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"`+verda.LogKeyStatus+`":200`) {
+		t.Errorf("expected a response record with the status, got %q", buf.String())
+	}
+}
@@ -21,7 +21,6 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
-	"os"
 	"sort"
 	"testing"
 	"time"
@@ -29,31 +28,23 @@ import (
 	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
 )
 
-// getTestClient creates a client for integration tests using environment variables.
-// Use VERDA_CLIENT_ID and VERDA_CLIENT_SECRET only; never log or commit credentials.
+// getTestClient creates a client for integration tests from VERDA_* environment
+// variables or the profile selected by VERDA_PROFILE; never log or commit credentials.
 func getTestClient(t *testing.T) *verda.Client {
 	t.Helper()
 
-	// Get credentials and configuration from environment
-	clientID := os.Getenv("VERDA_CLIENT_ID")
-	clientSecret := os.Getenv("VERDA_CLIENT_SECRET")
-	baseURL := os.Getenv("VERDA_BASE_URL") // Optional: defaults to production
-
-	if clientID == "" || clientSecret == "" {
-		t.Skip("VERDA_CLIENT_ID and VERDA_CLIENT_SECRET must be set for integration tests")
+	cfg, err := verda.LoadConfig()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
 	}
-
-	// Create client with optional base URL override
-	options := []verda.ClientOption{
-		verda.WithClientID(clientID),
-		verda.WithClientSecret(clientSecret),
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		t.Skip("VERDA_CLIENT_ID and VERDA_CLIENT_SECRET (or a config profile) must be set for integration tests")
 	}
-	if baseURL != "" {
-		options = append(options, verda.WithBaseURL(baseURL))
-		t.Logf("Using custom base URL: %s", baseURL)
+	if source := cfg.Source(verda.SettingBaseURL); source.Kind != verda.SourceDefault {
+		t.Logf("Using custom base URL: %s (from %s)", cfg.BaseURL, source)
 	}
 
-	client, err := verda.NewClient(options...)
+	client, err := verda.NewClient(verda.WithConfig(cfg))
 	if err != nil {
 		t.Fatalf("failed to create client: %v", err)
 	}